@@ -0,0 +1,176 @@
+package auth
+
+import (
+	"context"
+	"crypto/x509"
+	"fmt"
+	"strings"
+
+	"github.com/anchamber/genetics-tank/grpcutil"
+	"github.com/anchamber/genetics-tank/logging"
+	"github.com/golang-jwt/jwt/v4"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/credentials"
+	"google.golang.org/grpc/metadata"
+	"google.golang.org/grpc/peer"
+	"google.golang.org/grpc/status"
+)
+
+// Permissions maps the full gRPC method name to the minimal role needed to call it.
+var Permissions = map[string]Role{
+	"/anchamber.genetics.TankService/StreamTanks":        Viewer,
+	"/anchamber.genetics.TankService/CountTanks":         Viewer,
+	"/anchamber.genetics.TankService/SearchTanks":        Viewer,
+	"/anchamber.genetics.TankService/GetTank":            Viewer,
+	"/anchamber.genetics.TankService/GenerateTankLabels": Viewer,
+	"/anchamber.genetics.TankService/ResolveScan":        Viewer,
+	"/anchamber.genetics.TankService/UpdateTank":         Technician,
+	"/anchamber.genetics.TankService/ScanAction":         Technician,
+	"/anchamber.genetics.TankService/CreateTank":         Admin,
+	"/anchamber.genetics.TankService/DeleteTank":         Admin,
+}
+
+// PublicMethods can be called without authentication.
+var PublicMethods = map[string]bool{
+	"/grpc.health.v1.Health/Check":                                   true,
+	"/grpc.health.v1.Health/Watch":                                   true,
+	"/grpc.reflection.v1alpha.ServerReflection/ServerReflectionInfo": true,
+	"/grpc.reflection.v1.ServerReflection/ServerReflectionInfo":      true,
+}
+
+// Claims are the JWT claims expected in a bearer token. A token has access
+// to the tanks in its systems and labs, or to all tanks if All is set.
+type Claims struct {
+	Roles   []Role   `json:"roles"`
+	Systems []string `json:"systems,omitempty"`
+	Labs    []string `json:"labs,omitempty"`
+	All     bool     `json:"all,omitempty"`
+	jwt.RegisteredClaims
+}
+
+type Authenticator struct {
+	key []byte
+	// TrustedProxy reports whether a client certificate belongs to a proxy
+	// like the gateway. Requests of a proxy always need a bearer token.
+	TrustedProxy func(cert *x509.Certificate) bool
+	// CertificateRoles are the roles a client certificate can claim with its
+	// organizational units. Every certificate signed by the client CA can
+	// claim all of them, so Admin should only be included if the CA signs
+	// certificates for administrators only.
+	CertificateRoles []Role
+}
+
+func New(key []byte) *Authenticator {
+	return &Authenticator{
+		key:              key,
+		CertificateRoles: []Role{Viewer, Technician},
+	}
+}
+
+func (a *Authenticator) UnaryInterceptor(ctx context.Context, req interface{}, info *grpc.UnaryServerInfo, handler grpc.UnaryHandler) (interface{}, error) {
+	if PublicMethods[info.FullMethod] {
+		return handler(ctx, req)
+	}
+	ctx, err := a.authorize(ctx, info.FullMethod)
+	if err != nil {
+		return nil, err
+	}
+	return handler(ctx, req)
+}
+
+func (a *Authenticator) StreamInterceptor(srv interface{}, ss grpc.ServerStream, info *grpc.StreamServerInfo, handler grpc.StreamHandler) error {
+	if PublicMethods[info.FullMethod] {
+		return handler(srv, ss)
+	}
+	ctx, err := a.authorize(ss.Context(), info.FullMethod)
+	if err != nil {
+		return err
+	}
+	return handler(srv, grpcutil.WithContext(ss, ctx))
+}
+
+// authorize validates the bearer token of the request and checks that the
+// principal is allowed to call method. Without a token the principal is taken
+// from the verified client certificate, if there is one.
+// The returned context carries the principal.
+func (a *Authenticator) authorize(ctx context.Context, method string) (context.Context, error) {
+	role, ok := Permissions[method]
+	if !ok {
+		return nil, status.Error(codes.PermissionDenied, "method not permitted")
+	}
+	var principal *Principal
+	token, err := bearerToken(ctx)
+	if err == nil {
+		principal, err = a.Validate(token)
+		if err != nil {
+			logging.FromContext(ctx, nil).Warn("invalid token", "error", err)
+			return nil, status.Error(codes.Unauthenticated, "invalid token")
+		}
+	} else if cert := peerCertificate(ctx); cert != nil && (a.TrustedProxy == nil || !a.TrustedProxy(cert)) {
+		principal = PrincipalFromCertificate(cert, a.CertificateRoles)
+	} else {
+		return nil, err
+	}
+	if !principal.HasRole(role) {
+		return nil, status.Error(codes.PermissionDenied, fmt.Sprintf("role '%s' required", role))
+	}
+	return NewContext(ctx, principal), nil
+}
+
+// Validate checks the signature and expiry of an HMAC signed JWT and
+// returns the principal it describes. Tokens without expiry are rejected.
+func (a *Authenticator) Validate(token string) (*Principal, error) {
+	claims := &Claims{}
+	_, err := jwt.ParseWithClaims(token, claims, func(t *jwt.Token) (interface{}, error) {
+		if _, ok := t.Method.(*jwt.SigningMethodHMAC); !ok {
+			return nil, fmt.Errorf("unexpected signing method %v", t.Header["alg"])
+		}
+		return a.key, nil
+	})
+	if err != nil {
+		return nil, err
+	}
+	if claims.ExpiresAt == nil {
+		return nil, fmt.Errorf("token has no expiry")
+	}
+	if claims.Subject == "" {
+		return nil, fmt.Errorf("token has no subject")
+	}
+	return &Principal{
+		Subject:  claims.Subject,
+		Roles:    claims.Roles,
+		Systems:  claims.Systems,
+		Labs:     claims.Labs,
+		AllTanks: claims.All,
+	}, nil
+}
+
+func bearerToken(ctx context.Context) (string, error) {
+	md, ok := metadata.FromIncomingContext(ctx)
+	if !ok {
+		return "", status.Error(codes.Unauthenticated, "missing metadata")
+	}
+	values := md.Get("authorization")
+	if len(values) == 0 {
+		return "", status.Error(codes.Unauthenticated, "missing authorization token")
+	}
+	const prefix = "bearer "
+	if len(values[0]) <= len(prefix) || !strings.EqualFold(values[0][:len(prefix)], prefix) {
+		return "", status.Error(codes.Unauthenticated, "authorization is not a bearer token")
+	}
+	return values[0][len(prefix):], nil
+}
+
+// peerCertificate returns the verified client certificate of the connection.
+func peerCertificate(ctx context.Context) *x509.Certificate {
+	p, ok := peer.FromContext(ctx)
+	if !ok {
+		return nil
+	}
+	tlsInfo, ok := p.AuthInfo.(credentials.TLSInfo)
+	if !ok || len(tlsInfo.State.VerifiedChains) == 0 || len(tlsInfo.State.VerifiedChains[0]) == 0 {
+		return nil
+	}
+	return tlsInfo.State.VerifiedChains[0][0]
+}
@@ -0,0 +1,154 @@
+package auth_test
+
+import (
+	"context"
+	"crypto/x509"
+	"crypto/x509/pkix"
+	"testing"
+	"time"
+
+	"github.com/anchamber/genetics-tank/auth"
+	"github.com/golang-jwt/jwt/v4"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/metadata"
+	"google.golang.org/grpc/status"
+)
+
+var testKey = []byte("test-key")
+
+// createToken signs a token for subject that expires after expiresIn or, if
+// expiresIn is 0, has no expiry.
+func createToken(t *testing.T, key []byte, method jwt.SigningMethod, subject string, expiresIn time.Duration, roles ...auth.Role) string {
+	claims := auth.Claims{
+		Roles: roles,
+		RegisteredClaims: jwt.RegisteredClaims{
+			Subject: subject,
+		},
+	}
+	if expiresIn != 0 {
+		claims.ExpiresAt = jwt.NewNumericDate(time.Now().Add(expiresIn))
+	}
+	token, err := jwt.NewWithClaims(method, claims).SignedString(key)
+	if err != nil {
+		t.Fatalf("failed to sign token: %v", err)
+	}
+	return token
+}
+
+func TestUnaryInterceptor(t *testing.T) {
+	testCases := []struct {
+		name      string
+		method    string
+		token     string
+		errorCode codes.Code
+	}{
+		{
+			name:      "viewer can get",
+			method:    "/anchamber.genetics.TankService/GetTank",
+			token:     "Bearer " + createToken(t, testKey, jwt.SigningMethodHS256, "viewer", time.Hour, auth.Viewer),
+			errorCode: codes.OK,
+		},
+		{
+			name:      "viewer can not update",
+			method:    "/anchamber.genetics.TankService/UpdateTank",
+			token:     "Bearer " + createToken(t, testKey, jwt.SigningMethodHS256, "viewer", time.Hour, auth.Viewer),
+			errorCode: codes.PermissionDenied,
+		},
+		{
+			name:      "technician can update",
+			method:    "/anchamber.genetics.TankService/UpdateTank",
+			token:     "Bearer " + createToken(t, testKey, jwt.SigningMethodHS256, "tech", time.Hour, auth.Technician),
+			errorCode: codes.OK,
+		},
+		{
+			name:      "technician can not delete",
+			method:    "/anchamber.genetics.TankService/DeleteTank",
+			token:     "Bearer " + createToken(t, testKey, jwt.SigningMethodHS256, "tech", time.Hour, auth.Technician),
+			errorCode: codes.PermissionDenied,
+		},
+		{
+			name:      "admin can delete",
+			method:    "/anchamber.genetics.TankService/DeleteTank",
+			token:     "Bearer " + createToken(t, testKey, jwt.SigningMethodHS256, "admin", time.Hour, auth.Admin),
+			errorCode: codes.OK,
+		},
+		{
+			name:      "missing token",
+			method:    "/anchamber.genetics.TankService/GetTank",
+			token:     "",
+			errorCode: codes.Unauthenticated,
+		},
+		{
+			name:      "wrong key",
+			method:    "/anchamber.genetics.TankService/GetTank",
+			token:     "Bearer " + createToken(t, []byte("other-key"), jwt.SigningMethodHS256, "admin", time.Hour, auth.Admin),
+			errorCode: codes.Unauthenticated,
+		},
+		{
+			name:      "expired token",
+			method:    "/anchamber.genetics.TankService/GetTank",
+			token:     "Bearer " + createToken(t, testKey, jwt.SigningMethodHS256, "admin", -time.Hour, auth.Admin),
+			errorCode: codes.Unauthenticated,
+		},
+		{
+			name:      "token without expiry",
+			method:    "/anchamber.genetics.TankService/GetTank",
+			token:     "Bearer " + createToken(t, testKey, jwt.SigningMethodHS256, "admin", 0, auth.Admin),
+			errorCode: codes.Unauthenticated,
+		},
+		{
+			name:      "unknown method",
+			method:    "/anchamber.genetics.TankService/Unknown",
+			token:     "Bearer " + createToken(t, testKey, jwt.SigningMethodHS256, "admin", time.Hour, auth.Admin),
+			errorCode: codes.PermissionDenied,
+		},
+	}
+
+	authenticator := auth.New(testKey)
+	for _, tc := range testCases {
+		tc := tc
+		t.Run(tc.name, func(t *testing.T) {
+			t.Parallel()
+			ctx := context.Background()
+			if tc.token != "" {
+				ctx = metadata.NewIncomingContext(ctx, metadata.Pairs("authorization", tc.token))
+			}
+			called := false
+			_, err := authenticator.UnaryInterceptor(ctx, nil, &grpc.UnaryServerInfo{FullMethod: tc.method},
+				func(ctx context.Context, req interface{}) (interface{}, error) {
+					called = true
+					if _, ok := auth.FromContext(ctx); !ok {
+						t.Error("principal missing in context")
+					}
+					return nil, nil
+				})
+			if status.Code(err) != tc.errorCode {
+				t.Fatalf("wrong status code: expected %v | actual: %v", tc.errorCode, status.Code(err))
+			}
+			if called != (tc.errorCode == codes.OK) {
+				t.Errorf("handler called: %v, expected: %v", called, tc.errorCode == codes.OK)
+			}
+		})
+	}
+}
+
+func TestPrincipalFromCertificate(t *testing.T) {
+	cert := &x509.Certificate{
+		Subject: pkix.Name{
+			CommonName:         "scanner-1",
+			Organization:       []string{"medaka"},
+			OrganizationalUnit: []string{string(auth.Technician), string(auth.Admin)},
+		},
+	}
+	principal := auth.PrincipalFromCertificate(cert, []auth.Role{auth.Viewer, auth.Technician})
+	if principal.Subject != "scanner-1" {
+		t.Errorf("subjects do not match, expected: %s | actual: %s", "scanner-1", principal.Subject)
+	}
+	if !principal.HasRole(auth.Technician) || principal.HasRole(auth.Admin) {
+		t.Errorf("roles do not match, expected: %v | actual: %v", auth.Technician, principal.Roles)
+	}
+	if len(principal.Labs) != 1 || principal.Labs[0] != "medaka" {
+		t.Errorf("labs do not match, expected: %v | actual: %v", []string{"medaka"}, principal.Labs)
+	}
+}
@@ -0,0 +1,80 @@
+package auth
+
+import (
+	"context"
+	"crypto/x509"
+)
+
+type Role string
+
+const (
+	Viewer     Role = "viewer"
+	Technician Role = "technician"
+	Admin      Role = "admin"
+)
+
+// Valid reports whether r is one of the known roles.
+func (r Role) Valid() bool {
+	_, ok := rank[r]
+	return ok
+}
+
+// rank orders the roles so that a higher role includes all permissions of
+// the lower ones.
+var rank = map[Role]int{
+	Viewer:     1,
+	Technician: 2,
+	Admin:      3,
+}
+
+// Principal is the authenticated caller of an RPC.
+// Systems and Labs limit the tanks the principal can see and modify,
+// a principal without either has access to no tanks unless AllTanks is set.
+type Principal struct {
+	Subject  string
+	Roles    []Role
+	Systems  []string
+	Labs     []string
+	AllTanks bool
+}
+
+// HasRole reports whether the principal has the given role or one ranked above it.
+func (p *Principal) HasRole(role Role) bool {
+	for _, r := range p.Roles {
+		if rank[r] >= rank[role] && rank[r] > 0 {
+			return true
+		}
+	}
+	return false
+}
+
+// PrincipalFromCertificate maps the subject of a verified client certificate
+// to a principal. The common name is used as subject, the organizational units
+// as roles and the organizations as labs. Organizational units that are not
+// one of the allowed roles are ignored.
+func PrincipalFromCertificate(cert *x509.Certificate, allowed []Role) *Principal {
+	roles := make([]Role, 0, len(cert.Subject.OrganizationalUnit))
+	for _, unit := range cert.Subject.OrganizationalUnit {
+		for _, role := range allowed {
+			if Role(unit) == role {
+				roles = append(roles, role)
+			}
+		}
+	}
+	return &Principal{
+		Subject: cert.Subject.CommonName,
+		Roles:   roles,
+		Labs:    cert.Subject.Organization,
+	}
+}
+
+type principalKey struct{}
+
+func NewContext(ctx context.Context, principal *Principal) context.Context {
+	return context.WithValue(ctx, principalKey{}, principal)
+}
+
+func FromContext(ctx context.Context) (*Principal, bool) {
+	principal, ok := ctx.Value(principalKey{}).(*Principal)
+	return principal, ok
+}
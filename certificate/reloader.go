@@ -0,0 +1,224 @@
+package certificate
+
+import (
+	"bytes"
+	"crypto/tls"
+	"crypto/x509"
+	"errors"
+	"fmt"
+	"log/slog"
+	"os"
+	"sync"
+	"time"
+)
+
+// Reloader holds the server certificate and the optional CA bundle used to
+// verify client certificates. Both are reloaded when their files change.
+type Reloader struct {
+	certFile string
+	keyFile  string
+	caFile   string
+
+	mu        sync.RWMutex
+	cert      *tls.Certificate
+	clientCAs *x509.CertPool
+	modTimes  map[string]time.Time
+	// loaded holds the DER of every server certificate loaded so far.
+	loaded map[string]bool
+
+	done chan struct{}
+}
+
+// NewReloader loads the certificate and key and, if caFile is not empty,
+// the CA bundle that client certificates have to be signed by. The gateway
+// presents the server certificate as client certificate, so with a CA bundle
+// the server certificate has to be signed by it and allow client
+// authentication.
+func NewReloader(certFile string, keyFile string, caFile string) (*Reloader, error) {
+	r := &Reloader{
+		certFile: certFile,
+		keyFile:  keyFile,
+		caFile:   caFile,
+		modTimes: make(map[string]time.Time),
+		loaded:   make(map[string]bool),
+		done:     make(chan struct{}),
+	}
+	if err := r.reload(); err != nil {
+		return nil, err
+	}
+	return r, nil
+}
+
+// Watch checks the files for changes every interval until Stop is called.
+func (r *Reloader) Watch(interval time.Duration) {
+	go func() {
+		ticker := time.NewTicker(interval)
+		defer ticker.Stop()
+		for {
+			select {
+			case <-r.done:
+				return
+			case <-ticker.C:
+				if !r.changed() {
+					continue
+				}
+				if err := r.reload(); err != nil {
+					slog.Error("failed to reload certificates, keeping the current ones", "error", err)
+				} else {
+					slog.Info("reloaded certificates", "cert", r.certFile)
+				}
+			}
+		}
+	}()
+}
+
+func (r *Reloader) Stop() {
+	close(r.done)
+}
+
+func (r *Reloader) GetCertificate(*tls.ClientHelloInfo) (*tls.Certificate, error) {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+	return r.cert, nil
+}
+
+// IsServerCertificate reports whether cert is the current or a previous
+// server certificate. Replaced certificates stay trusted because the
+// connections the gateway opened before a reload keep presenting them.
+func (r *Reloader) IsServerCertificate(cert *x509.Certificate) bool {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+	return r.loaded[string(cert.Raw)]
+}
+
+// ServerConfig returns the TLS configuration for the gRPC server. If a CA
+// bundle is configured, clients have to present a certificate signed by it.
+func (r *Reloader) ServerConfig() *tls.Config {
+	return &tls.Config{
+		MinVersion:     tls.VersionTLS12,
+		GetCertificate: r.GetCertificate,
+		GetConfigForClient: func(*tls.ClientHelloInfo) (*tls.Config, error) {
+			r.mu.RLock()
+			defer r.mu.RUnlock()
+			config := &tls.Config{
+				MinVersion:     tls.VersionTLS12,
+				GetCertificate: r.GetCertificate,
+			}
+			if r.clientCAs != nil {
+				config.ClientAuth = tls.RequireAndVerifyClientCert
+				config.ClientCAs = r.clientCAs
+			}
+			return config, nil
+		},
+	}
+}
+
+// LoopbackConfig returns the TLS configuration for connecting to this server
+// from within the process, e.g. by the gateway. The server certificate is
+// pinned and the same certificate is presented as client certificate, which
+// reload checks against the client CA bundle.
+func (r *Reloader) LoopbackConfig() *tls.Config {
+	return &tls.Config{
+		MinVersion: tls.VersionTLS12,
+		// verification is done by pinning the certificate in VerifyPeerCertificate
+		InsecureSkipVerify: true,
+		VerifyPeerCertificate: func(rawCerts [][]byte, _ [][]*x509.Certificate) error {
+			r.mu.RLock()
+			defer r.mu.RUnlock()
+			if len(rawCerts) == 0 || !bytes.Equal(rawCerts[0], r.cert.Certificate[0]) {
+				return errors.New("server certificate does not match")
+			}
+			return nil
+		},
+		GetClientCertificate: func(*tls.CertificateRequestInfo) (*tls.Certificate, error) {
+			return r.GetCertificate(nil)
+		},
+	}
+}
+
+func (r *Reloader) files() []string {
+	files := []string{r.certFile, r.keyFile}
+	if r.caFile != "" {
+		files = append(files, r.caFile)
+	}
+	return files
+}
+
+func (r *Reloader) changed() bool {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+	for _, file := range r.files() {
+		info, err := os.Stat(file)
+		if err != nil {
+			continue
+		}
+		if !info.ModTime().Equal(r.modTimes[file]) {
+			return true
+		}
+	}
+	return false
+}
+
+func (r *Reloader) reload() error {
+	modTimes := make(map[string]time.Time)
+	for _, file := range r.files() {
+		info, err := os.Stat(file)
+		if err != nil {
+			return err
+		}
+		modTimes[file] = info.ModTime()
+	}
+
+	cert, err := tls.LoadX509KeyPair(r.certFile, r.keyFile)
+	if err != nil {
+		return fmt.Errorf("failed to load key pair: %w", err)
+	}
+	var clientCAs *x509.CertPool
+	if r.caFile != "" {
+		pem, err := os.ReadFile(r.caFile)
+		if err != nil {
+			return err
+		}
+		clientCAs = x509.NewCertPool()
+		if !clientCAs.AppendCertsFromPEM(pem) {
+			return fmt.Errorf("no certificates found in '%s'", r.caFile)
+		}
+	}
+
+	if clientCAs != nil {
+		if err := verifyClientCertificate(&cert, clientCAs); err != nil {
+			return fmt.Errorf("the server certificate is used as client certificate by the gateway but is not accepted by '%s': %w", r.caFile, err)
+		}
+	}
+
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	r.cert = &cert
+	r.loaded[string(cert.Certificate[0])] = true
+	r.clientCAs = clientCAs
+	r.modTimes = modTimes
+	return nil
+}
+
+// verifyClientCertificate checks that cert is accepted as client certificate
+// by a server trusting clientCAs.
+func verifyClientCertificate(cert *tls.Certificate, clientCAs *x509.CertPool) error {
+	leaf, err := x509.ParseCertificate(cert.Certificate[0])
+	if err != nil {
+		return err
+	}
+	intermediates := x509.NewCertPool()
+	for _, der := range cert.Certificate[1:] {
+		intermediate, err := x509.ParseCertificate(der)
+		if err != nil {
+			return err
+		}
+		intermediates.AddCert(intermediate)
+	}
+	_, err = leaf.Verify(x509.VerifyOptions{
+		Roots:         clientCAs,
+		Intermediates: intermediates,
+		KeyUsages:     []x509.ExtKeyUsage{x509.ExtKeyUsageClientAuth},
+	})
+	return err
+}
@@ -0,0 +1,134 @@
+package certificate_test
+
+import (
+	"bytes"
+	"crypto/ecdsa"
+	"crypto/elliptic"
+	"crypto/rand"
+	"crypto/tls"
+	"crypto/x509"
+	"crypto/x509/pkix"
+	"encoding/pem"
+	"math/big"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/anchamber/genetics-tank/certificate"
+)
+
+func writeCertificate(t *testing.T, dir string, commonName string, modTime time.Time, extKeyUsage ...x509.ExtKeyUsage) (string, string) {
+	key, err := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+	if err != nil {
+		t.Fatal(err)
+	}
+	template := &x509.Certificate{
+		SerialNumber: big.NewInt(time.Now().UnixNano()),
+		Subject:      pkix.Name{CommonName: commonName},
+		NotBefore:    time.Now().Add(-time.Hour),
+		NotAfter:     time.Now().Add(time.Hour),
+		IsCA:         true,
+		KeyUsage:     x509.KeyUsageDigitalSignature | x509.KeyUsageCertSign,
+		DNSNames:     []string{"localhost"},
+		ExtKeyUsage:  extKeyUsage,
+	}
+	der, err := x509.CreateCertificate(rand.Reader, template, template, &key.PublicKey, key)
+	if err != nil {
+		t.Fatal(err)
+	}
+	keyDer, err := x509.MarshalECPrivateKey(key)
+	if err != nil {
+		t.Fatal(err)
+	}
+	certFile := filepath.Join(dir, "tls.crt")
+	keyFile := filepath.Join(dir, "tls.key")
+	if err := os.WriteFile(certFile, pem.EncodeToMemory(&pem.Block{Type: "CERTIFICATE", Bytes: der}), 0600); err != nil {
+		t.Fatal(err)
+	}
+	if err := os.WriteFile(keyFile, pem.EncodeToMemory(&pem.Block{Type: "EC PRIVATE KEY", Bytes: keyDer}), 0600); err != nil {
+		t.Fatal(err)
+	}
+	for _, file := range []string{certFile, keyFile} {
+		if err := os.Chtimes(file, modTime, modTime); err != nil {
+			t.Fatal(err)
+		}
+	}
+	return certFile, keyFile
+}
+
+func TestReloader(t *testing.T) {
+	dir := t.TempDir()
+	certFile, keyFile := writeCertificate(t, dir, "first", time.Now().Add(-time.Minute))
+
+	reloader, err := certificate.NewReloader(certFile, keyFile, "")
+	if err != nil {
+		t.Fatalf("failed to create reloader: %v", err)
+	}
+	defer reloader.Stop()
+	first, _ := reloader.GetCertificate(nil)
+
+	config, _ := reloader.ServerConfig().GetConfigForClient(nil)
+	if config.ClientAuth != tls.NoClientCert {
+		t.Errorf("client certificate should not be required without CA bundle")
+	}
+
+	reloader.Watch(10 * time.Millisecond)
+	writeCertificate(t, dir, "second", time.Now())
+	deadline := time.Now().Add(2 * time.Second)
+	for time.Now().Before(deadline) {
+		current, _ := reloader.GetCertificate(nil)
+		if !bytes.Equal(current.Certificate[0], first.Certificate[0]) {
+			for _, cert := range []*tls.Certificate{first, current} {
+				leaf, err := x509.ParseCertificate(cert.Certificate[0])
+				if err != nil {
+					t.Fatal(err)
+				}
+				if !reloader.IsServerCertificate(leaf) {
+					t.Errorf("%s should be a server certificate", leaf.Subject.CommonName)
+				}
+			}
+			return
+		}
+		time.Sleep(10 * time.Millisecond)
+	}
+	t.Errorf("certificate was not reloaded")
+}
+
+func TestReloaderWithClientCA(t *testing.T) {
+	dir := t.TempDir()
+	certFile, keyFile := writeCertificate(t, dir, "server", time.Now())
+
+	reloader, err := certificate.NewReloader(certFile, keyFile, certFile)
+	if err != nil {
+		t.Fatalf("failed to create reloader: %v", err)
+	}
+	defer reloader.Stop()
+	config, _ := reloader.ServerConfig().GetConfigForClient(nil)
+	if config.ClientAuth != tls.RequireAndVerifyClientCert {
+		t.Errorf("client certificate should be required with CA bundle")
+	}
+	if config.ClientCAs == nil {
+		t.Errorf("client CAs should be set")
+	}
+
+	_, err = certificate.NewReloader(certFile, keyFile, filepath.Join(dir, "missing.crt"))
+	if err == nil {
+		t.Errorf("missing CA bundle should fail")
+	}
+}
+
+func TestReloaderRejectsLoopbackCertificate(t *testing.T) {
+	dir := t.TempDir()
+	caFile, _ := writeCertificate(t, dir, "ca", time.Now())
+	otherDir := t.TempDir()
+	certFile, keyFile := writeCertificate(t, otherDir, "server", time.Now())
+	if _, err := certificate.NewReloader(certFile, keyFile, caFile); err == nil {
+		t.Errorf("server certificate not signed by the client CA should fail")
+	}
+
+	certFile, keyFile = writeCertificate(t, otherDir, "server", time.Now(), x509.ExtKeyUsageServerAuth)
+	if _, err := certificate.NewReloader(certFile, keyFile, certFile); err == nil {
+		t.Errorf("server certificate without client authentication should fail")
+	}
+}
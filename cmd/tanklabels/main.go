@@ -0,0 +1,146 @@
+// Command tanklabels renders tank labels with the GenerateTankLabels RPC and
+// writes the pages to files or, for a single page, to stdout.
+//
+//	tanklabels -numbers 1,2,3 -format svg -out labels
+//	tanklabels -filter system=A -format zpl -code qr -out - | nc printer 9100
+package main
+
+import (
+	"context"
+	"crypto/tls"
+	"crypto/x509"
+	"flag"
+	"fmt"
+	"os"
+	"strconv"
+	"strings"
+	"time"
+
+	apiProto "github.com/anchamber/genetics-api/proto"
+	pb "github.com/anchamber/genetics-tank/proto"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/credentials"
+	"google.golang.org/grpc/credentials/insecure"
+	"google.golang.org/grpc/metadata"
+)
+
+// filterFlags collects repeated -filter key=value flags.
+type filterFlags []*apiProto.Filter
+
+func (f *filterFlags) String() string {
+	return fmt.Sprint(*f)
+}
+
+func (f *filterFlags) Set(value string) error {
+	key, v, ok := strings.Cut(value, "=")
+	if !ok {
+		return fmt.Errorf("filter %q is not key=value", value)
+	}
+	*f = append(*f, &apiProto.Filter{Key: key, Operator: apiProto.Operator_EQ, Value: v})
+	return nil
+}
+
+func main() {
+	addr := flag.String("addr", "localhost:10000", "address of the tank service")
+	token := flag.String("token", os.Getenv("TANK_TOKEN"), "bearer token, defaults to $TANK_TOKEN")
+	caFile := flag.String("ca", "", "CA bundle to verify the server with, enables TLS")
+	numbers := flag.String("numbers", "", "comma separated tank numbers")
+	var filters filterFlags
+	flag.Var(&filters, "filter", "only label tanks where key equals value, e.g. system=A, can be repeated")
+	format := flag.String("format", "svg", "svg, png or zpl")
+	code := flag.String("code", "code128", "code128 or qr")
+	template := flag.String("template", "", "name of the label template")
+	out := flag.String("out", "labels", "prefix of the output files or - for stdout")
+	timeout := flag.Duration("timeout", 30*time.Second, "timeout of the request")
+	flag.Parse()
+
+	request := &pb.GenerateTankLabelsRequest{Filters: filters, Template: *template}
+	var err error
+	if request.Numbers, err = parseNumbers(*numbers); err != nil {
+		fail(err)
+	}
+	formatValue, ok := pb.LabelFormat_value[strings.ToUpper(*format)]
+	if !ok {
+		fail(fmt.Errorf("unknown format %q", *format))
+	}
+	request.Format = pb.LabelFormat(formatValue)
+	symbologyValue, ok := pb.Symbology_value[strings.ToUpper(*code)]
+	if !ok {
+		fail(fmt.Errorf("unknown code %q", *code))
+	}
+	request.Symbology = pb.Symbology(symbologyValue)
+
+	transportCredentials := insecure.NewCredentials()
+	if *caFile != "" {
+		pem, err := os.ReadFile(*caFile)
+		if err != nil {
+			fail(err)
+		}
+		roots := x509.NewCertPool()
+		if !roots.AppendCertsFromPEM(pem) {
+			fail(fmt.Errorf("no certificates in %s", *caFile))
+		}
+		transportCredentials = credentials.NewTLS(&tls.Config{MinVersion: tls.VersionTLS12, RootCAs: roots})
+	}
+	conn, err := grpc.Dial(*addr, grpc.WithTransportCredentials(transportCredentials))
+	if err != nil {
+		fail(err)
+	}
+	defer conn.Close()
+
+	ctx, cancel := context.WithTimeout(context.Background(), *timeout)
+	defer cancel()
+	if *token != "" {
+		ctx = metadata.AppendToOutgoingContext(ctx, "authorization", "Bearer "+*token)
+	}
+	response, err := pb.NewTankServiceClient(conn).GenerateTankLabels(ctx, request)
+	if err != nil {
+		fail(err)
+	}
+	if err := write(response.Pages, *out, strings.ToLower(*format)); err != nil {
+		fail(err)
+	}
+}
+
+func parseNumbers(numbers string) ([]uint32, error) {
+	var parsed []uint32
+	for _, number := range strings.Split(numbers, ",") {
+		if number = strings.TrimSpace(number); number == "" {
+			continue
+		}
+		n, err := strconv.ParseUint(number, 10, 32)
+		if err != nil {
+			return nil, fmt.Errorf("invalid tank number %q", number)
+		}
+		parsed = append(parsed, uint32(n))
+	}
+	return parsed, nil
+}
+
+// write writes every page to its own file named after prefix and the page
+// number, or a single page to stdout if prefix is -.
+func write(pages [][]byte, prefix string, extension string) error {
+	if prefix == "-" {
+		if len(pages) != 1 {
+			return fmt.Errorf("%d pages can't be written to stdout, use -out", len(pages))
+		}
+		_, err := os.Stdout.Write(pages[0])
+		return err
+	}
+	for i, page := range pages {
+		name := fmt.Sprintf("%s.%s", prefix, extension)
+		if len(pages) > 1 {
+			name = fmt.Sprintf("%s-%d.%s", prefix, i+1, extension)
+		}
+		if err := os.WriteFile(name, page, 0o644); err != nil {
+			return err
+		}
+		fmt.Fprintln(os.Stderr, "wrote", name)
+	}
+	return nil
+}
+
+func fail(err error) {
+	fmt.Fprintln(os.Stderr, "tanklabels:", err)
+	os.Exit(1)
+}
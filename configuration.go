@@ -1,25 +1,378 @@
 package main
 
 import (
+	"bytes"
+	"errors"
 	"fmt"
+	"io"
+	"log/slog"
 	"os"
+	"path/filepath"
+	"reflect"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/BurntSushi/toml"
+	"github.com/anchamber/genetics-tank/auth"
+	"github.com/anchamber/genetics-tank/logging"
+	"github.com/anchamber/genetics-tank/ratelimit"
+	"gopkg.in/yaml.v3"
 )
 
+// ConfigFileEnv names the environment variable with the path of the
+// configuration file. Without a file, the configuration is read from the
+// environment only.
+const ConfigFileEnv = "CONFIG_FILE"
+
+// Configuration is read from a YAML or TOML file, chosen by the extension of
+// the file. Every setting can be overridden by the environment variable in
+// its env tag. Settings tagged with reload are applied without a restart.
 type Configuration struct {
-	Port string
+	Port        string `yaml:"port" toml:"port" env:"PORT"`
+	GatewayPort string `yaml:"gateway_port" toml:"gateway_port" env:"GATEWAY_PORT"`
+	MetricsPort string `yaml:"metrics_port" toml:"metrics_port" env:"METRICS_PORT"`
+	AuthKey     string `yaml:"auth_key" toml:"auth_key" env:"AUTH_KEY"`
+	TLSCert     string `yaml:"tls_cert" toml:"tls_cert" env:"TLS_CERT"`
+	TLSKey      string `yaml:"tls_key" toml:"tls_key" env:"TLS_KEY"`
+	TLSClientCA string `yaml:"tls_client_ca" toml:"tls_client_ca" env:"TLS_CLIENT_CA"`
+	// CertRoles is a comma separated list of the roles client certificates
+	// can claim with their organizational units. Every certificate signed by
+	// tls_client_ca can claim them, so only add admin if the CA signs
+	// certificates for administrators only.
+	CertRoles     string `yaml:"cert_roles" toml:"cert_roles" env:"CERT_ROLES"`
+	LogLevel      string `yaml:"log_level" toml:"log_level" env:"LOG_LEVEL" reload:"true"`
+	TraceExporter string `yaml:"trace_exporter" toml:"trace_exporter" env:"TRACE_EXPORTER"`
+	TraceFile     string `yaml:"trace_file" toml:"trace_file" env:"TRACE_FILE"`
+	// ShutdownTimeout is the time in-flight RPCs get to finish on shutdown
+	// before the server is stopped forcefully, e.g. "30s".
+	ShutdownTimeout time.Duration `yaml:"shutdown_timeout" toml:"shutdown_timeout" env:"SHUTDOWN_TIMEOUT"`
+	// PageTokenKey signs page tokens. If empty, a random key is used and
+	// tokens become invalid on restart.
+	PageTokenKey string `yaml:"page_token_key" toml:"page_token_key" env:"PAGE_TOKEN_KEY"`
+	// CacheSize is the number of tanks and query results kept in memory.
+	// The cache is disabled if it is 0.
+	CacheSize int `yaml:"cache_size" toml:"cache_size" env:"CACHE_SIZE"`
+	// CacheTTL is how long query results are cached, e.g. "2s".
+	CacheTTL time.Duration `yaml:"cache_ttl" toml:"cache_ttl" env:"CACHE_TTL"`
+	// RateLimit is the number of requests per second a client can make on
+	// average, RateLimitBurst the number it can make at once. Requests are
+	// not limited if RateLimit is 0, the default.
+	RateLimit      float64 `yaml:"rate_limit" toml:"rate_limit" env:"RATE_LIMIT" reload:"true"`
+	RateLimitBurst int     `yaml:"rate_limit_burst" toml:"rate_limit_burst" env:"RATE_LIMIT_BURST" reload:"true"`
+	// MaxStreams and MaxStreamsPerClient limit the concurrent streams of all
+	// clients and of one client. 0, the default, means unlimited.
+	MaxStreams          int `yaml:"max_streams" toml:"max_streams" env:"MAX_STREAMS" reload:"true"`
+	MaxStreamsPerClient int `yaml:"max_streams_per_client" toml:"max_streams_per_client" env:"MAX_STREAMS_PER_CLIENT" reload:"true"`
+	// LabelTemplates is a YAML file with label templates in addition to the
+	// built-in ones.
+	LabelTemplates string `yaml:"label_templates" toml:"label_templates" env:"LABEL_TEMPLATES"`
 }
 
-func LoadConfiguration() Configuration {
+func DefaultConfiguration() Configuration {
 	return Configuration{
-		Port: loadEnv("PORT", "10000"),
+		Port:            "10000",
+		GatewayPort:     "8080",
+		MetricsPort:     "9090",
+		LogLevel:        "info",
+		TraceExporter:   "none",
+		TraceFile:       "traces.json",
+		CertRoles:       "viewer,technician",
+		ShutdownTimeout: 30 * time.Second,
+		CacheTTL:        2 * time.Second,
+	}
+}
+
+// LoadConfiguration reads the file at path, if path is not empty, on top of
+// the defaults, applies the environment overrides and validates the result.
+func LoadConfiguration(path string) (Configuration, error) {
+	configuration := DefaultConfiguration()
+	if path != "" {
+		if err := configuration.readFile(path); err != nil {
+			return configuration, err
+		}
+	}
+	if err := configuration.applyEnv(os.LookupEnv); err != nil {
+		return configuration, fmt.Errorf("invalid environment: %w", err)
+	}
+	if err := configuration.Validate(); err != nil {
+		return configuration, fmt.Errorf("invalid configuration: %w", err)
+	}
+	return configuration, nil
+}
+
+// readFile decodes the file at path. Unknown settings are an error, so typos
+// don't go unnoticed.
+func (c *Configuration) readFile(path string) error {
+	content, err := os.ReadFile(path)
+	if err != nil {
+		return fmt.Errorf("failed to read configuration: %w", err)
+	}
+	switch strings.ToLower(filepath.Ext(path)) {
+	case ".yaml", ".yml":
+		decoder := yaml.NewDecoder(bytes.NewReader(content))
+		decoder.KnownFields(true)
+		if err := decoder.Decode(c); err != nil && !errors.Is(err, io.EOF) {
+			return fmt.Errorf("failed to parse %s: %w", path, err)
+		}
+	case ".toml":
+		metadata, err := toml.Decode(string(content), c)
+		if err != nil {
+			return fmt.Errorf("failed to parse %s: %w", path, err)
+		}
+		if undecoded := metadata.Undecoded(); len(undecoded) > 0 {
+			return fmt.Errorf("failed to parse %s: unknown settings %v", path, undecoded)
+		}
+	default:
+		return fmt.Errorf("unsupported configuration format %q, use .yaml, .yml or .toml", filepath.Ext(path))
+	}
+	return nil
+}
+
+// applyEnv overrides every setting whose environment variable is set.
+func (c *Configuration) applyEnv(lookup func(string) (string, bool)) error {
+	var errs []error
+	value := reflect.ValueOf(c).Elem()
+	for i := 0; i < value.NumField(); i++ {
+		key := value.Type().Field(i).Tag.Get("env")
+		env, ok := lookup(key)
+		if key == "" || !ok {
+			continue
+		}
+		if err := setField(value.Field(i), env); err != nil {
+			errs = append(errs, fmt.Errorf("%s: %w", key, err))
+		}
+	}
+	return errors.Join(errs...)
+}
+
+func setField(field reflect.Value, value string) error {
+	switch field.Interface().(type) {
+	case string:
+		field.SetString(value)
+	case time.Duration:
+		d, err := time.ParseDuration(value)
+		if err != nil {
+			return err
+		}
+		field.SetInt(int64(d))
+	case int:
+		i, err := strconv.Atoi(value)
+		if err != nil {
+			return fmt.Errorf("%q is not an integer", value)
+		}
+		field.SetInt(int64(i))
+	case float64:
+		f, err := strconv.ParseFloat(value, 64)
+		if err != nil {
+			return fmt.Errorf("%q is not a number", value)
+		}
+		field.SetFloat(f)
+	default:
+		return fmt.Errorf("unsupported type %s", field.Type())
 	}
+	return nil
 }
 
-func loadEnv(key string, defaultValue string) string {
-	value, exists := os.LookupEnv(key)
-	if !exists {
-		fmt.Printf(fmt.Sprintf("Env variable '%s' not set. Use default value '%s'\n", key, defaultValue))
-		return defaultValue
+// Validate reports every invalid setting at once.
+func (c *Configuration) Validate() error {
+	var errs []error
+	invalid := func(setting string, format string, args ...interface{}) {
+		errs = append(errs, fmt.Errorf("%s: %s", setting, fmt.Sprintf(format, args...)))
+	}
+	for _, port := range []struct{ setting, value string }{
+		{"port", c.Port}, {"gateway_port", c.GatewayPort}, {"metrics_port", c.MetricsPort},
+	} {
+		if p, err := strconv.Atoi(port.value); err != nil || p < 1 || p > 65535 {
+			invalid(port.setting, "%q is not a port between 1 and 65535", port.value)
+		}
+	}
+	if c.AuthKey == "" {
+		invalid("auth_key", "needs to be set")
+	}
+	if (c.TLSCert == "") != (c.TLSKey == "") {
+		invalid("tls_key", "tls_cert and tls_key need to be set together")
+	}
+	if c.TLSClientCA != "" && c.TLSCert == "" {
+		invalid("tls_client_ca", "needs tls_cert and tls_key")
+	}
+	for _, role := range c.CertificateRoles() {
+		if !role.Valid() {
+			invalid("cert_roles", "unknown role %q, use viewer, technician or admin", role)
+		}
+	}
+	if _, err := logging.ParseLevel(c.LogLevel); err != nil {
+		invalid("log_level", "%v, use debug, info, warn or error", err)
+	}
+	switch c.TraceExporter {
+	case "", "none", "stdout":
+	case "file":
+		if c.TraceFile == "" {
+			invalid("trace_file", "needs to be set for the file exporter")
+		}
+	default:
+		invalid("trace_exporter", "unknown exporter %q, use none, stdout or file", c.TraceExporter)
+	}
+	if c.ShutdownTimeout <= 0 {
+		invalid("shutdown_timeout", "needs to be positive")
+	}
+	if c.CacheSize < 0 {
+		invalid("cache_size", "must not be negative")
+	}
+	if c.CacheTTL < 0 {
+		invalid("cache_ttl", "must not be negative")
+	}
+	if c.RateLimit < 0 {
+		invalid("rate_limit", "must not be negative")
+	}
+	if c.RateLimit > 0 && c.RateLimitBurst < 1 {
+		invalid("rate_limit_burst", "needs to be at least 1 if rate_limit is set")
+	}
+	if c.MaxStreams < 0 {
+		invalid("max_streams", "must not be negative")
+	}
+	if c.MaxStreamsPerClient < 0 {
+		invalid("max_streams_per_client", "must not be negative")
+	}
+	return errors.Join(errs...)
+}
+
+// CertificateRoles returns the roles client certificates can claim.
+func (c *Configuration) CertificateRoles() []auth.Role {
+	var roles []auth.Role
+	for _, role := range strings.Split(c.CertRoles, ",") {
+		if role = strings.TrimSpace(role); role != "" {
+			roles = append(roles, auth.Role(role))
+		}
+	}
+	return roles
+}
+
+// RateLimits returns the rate and concurrency limits of the configuration.
+func (c *Configuration) RateLimits() ratelimit.Options {
+	return ratelimit.Options{
+		Rate:                c.RateLimit,
+		Burst:               c.RateLimitBurst,
+		MaxStreams:          c.MaxStreams,
+		MaxStreamsPerClient: c.MaxStreamsPerClient,
+	}
+}
+
+// restartRequired returns the changed settings between c and other that are
+// only applied on start.
+func (c *Configuration) restartRequired(other Configuration) []string {
+	var settings []string
+	current := reflect.ValueOf(*c)
+	next := reflect.ValueOf(other)
+	for i := 0; i < current.NumField(); i++ {
+		field := current.Type().Field(i)
+		if field.Tag.Get("reload") == "true" {
+			continue
+		}
+		if current.Field(i).Interface() != next.Field(i).Interface() {
+			settings = append(settings, field.Tag.Get("yaml"))
+		}
+	}
+	return settings
+}
+
+// reloaded returns c with the settings that are applied without a restart
+// taken from other.
+func (c *Configuration) reloaded(other Configuration) Configuration {
+	merged := *c
+	value := reflect.ValueOf(&merged).Elem()
+	next := reflect.ValueOf(other)
+	for i := 0; i < value.NumField(); i++ {
+		if value.Type().Field(i).Tag.Get("reload") == "true" {
+			value.Field(i).Set(next.Field(i))
+		}
+	}
+	return merged
+}
+
+// ConfigurationWatcher reloads the configuration when Reload is called, e.g.
+// on SIGHUP, or when the configuration file changes, and passes it to apply.
+// An invalid configuration is logged and ignored. Settings that require a
+// restart keep their running values, so they are reported on every reload
+// until the restart.
+type ConfigurationWatcher struct {
+	path    string
+	current Configuration
+	apply   func(Configuration)
+	modTime time.Time
+	reload  chan struct{}
+	done    chan struct{}
+}
+
+func NewConfigurationWatcher(path string, current Configuration, apply func(Configuration)) *ConfigurationWatcher {
+	w := &ConfigurationWatcher{
+		path:    path,
+		current: current,
+		apply:   apply,
+		reload:  make(chan struct{}, 1),
+		done:    make(chan struct{}),
+	}
+	w.modTime, _ = w.fileModTime()
+	return w
+}
+
+// Reload reloads the configuration in the background.
+func (w *ConfigurationWatcher) Reload() {
+	select {
+	case w.reload <- struct{}{}:
+	default:
+	}
+}
+
+// Watch checks the configuration file for changes every interval and handles
+// calls of Reload until Stop is called.
+func (w *ConfigurationWatcher) Watch(interval time.Duration) {
+	go func() {
+		ticker := time.NewTicker(interval)
+		defer ticker.Stop()
+		for {
+			select {
+			case <-w.done:
+				return
+			case <-w.reload:
+				w.load()
+			case <-ticker.C:
+				modTime, err := w.fileModTime()
+				if err != nil || modTime.Equal(w.modTime) {
+					continue
+				}
+				w.modTime = modTime
+				w.load()
+			}
+		}
+	}()
+}
+
+func (w *ConfigurationWatcher) Stop() {
+	close(w.done)
+}
+
+func (w *ConfigurationWatcher) fileModTime() (time.Time, error) {
+	if w.path == "" {
+		return time.Time{}, os.ErrNotExist
+	}
+	info, err := os.Stat(w.path)
+	if err != nil {
+		return time.Time{}, err
+	}
+	return info.ModTime(), nil
+}
+
+func (w *ConfigurationWatcher) load() {
+	configuration, err := LoadConfiguration(w.path)
+	if err != nil {
+		slog.Error("failed to reload configuration, keeping the current one", "error", err)
+		return
+	}
+	if settings := w.current.restartRequired(configuration); len(settings) > 0 {
+		slog.Warn("changed settings are applied on restart only", "settings", settings)
 	}
-	return value
+	w.current = w.current.reloaded(configuration)
+	w.apply(w.current)
+	slog.Info("reloaded configuration", "file", w.path)
 }
@@ -0,0 +1,164 @@
+package main
+
+import (
+	"os"
+	"path/filepath"
+	"reflect"
+	"strings"
+	"testing"
+	"time"
+)
+
+func writeConfiguration(t *testing.T, name string, content string) string {
+	path := filepath.Join(t.TempDir(), name)
+	if err := os.WriteFile(path, []byte(content), 0o600); err != nil {
+		t.Fatal(err)
+	}
+	return path
+}
+
+func TestLoadConfiguration(t *testing.T) {
+	testCases := []struct {
+		name     string
+		file     string
+		content  string
+		env      map[string]string
+		expected func(c *Configuration)
+		errors   []string
+	}{
+		{
+			name:    "yaml",
+			file:    "config.yaml",
+			content: "auth_key: secret\nlog_level: debug\nshutdown_timeout: 5s\nrate_limit: 2.5\nrate_limit_burst: 5\n",
+			expected: func(c *Configuration) {
+				c.AuthKey = "secret"
+				c.LogLevel = "debug"
+				c.ShutdownTimeout = 5 * time.Second
+				c.RateLimit = 2.5
+				c.RateLimitBurst = 5
+			},
+		},
+		{
+			name:    "toml with env overrides",
+			file:    "config.toml",
+			content: "auth_key = \"secret\"\ncache_size = 100\ncache_ttl = \"1m\"\n",
+			env:     map[string]string{"CACHE_SIZE": "10", "PORT": "10001"},
+			expected: func(c *Configuration) {
+				c.AuthKey = "secret"
+				c.CacheSize = 10
+				c.CacheTTL = time.Minute
+				c.Port = "10001"
+			},
+		},
+		{
+			name:    "unknown setting",
+			file:    "config.yaml",
+			content: "auth_key: secret\nrate_limt: 5\n",
+			errors:  []string{"rate_limt"},
+		},
+		{
+			name:    "unsupported format",
+			file:    "config.json",
+			content: "{}",
+			errors:  []string{"unsupported configuration format"},
+		},
+		{
+			name:    "all invalid settings are reported",
+			file:    "config.yaml",
+			content: "port: \"0\"\nlog_level: verbose\ntls_cert: cert.pem\nmax_streams: -1\ncert_roles: viewer,root\n",
+			errors:  []string{"port:", "auth_key:", "tls_key:", "log_level:", "max_streams:", "cert_roles:"},
+		},
+		{
+			name:   "invalid environment",
+			env:    map[string]string{"AUTH_KEY": "secret", "RATE_LIMIT": "fast"},
+			errors: []string{"RATE_LIMIT"},
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			for key, value := range tc.env {
+				t.Setenv(key, value)
+			}
+			path := ""
+			if tc.file != "" {
+				path = writeConfiguration(t, tc.file, tc.content)
+			}
+			configuration, err := LoadConfiguration(path)
+			if len(tc.errors) > 0 {
+				if err == nil {
+					t.Fatal("expected an error")
+				}
+				for _, message := range tc.errors {
+					if !strings.Contains(err.Error(), message) {
+						t.Errorf("expected error to contain %q: %v", message, err)
+					}
+				}
+				return
+			}
+			if err != nil {
+				t.Fatal(err)
+			}
+			expected := DefaultConfiguration()
+			tc.expected(&expected)
+			if !reflect.DeepEqual(configuration, expected) {
+				t.Errorf("expected %+v, got %+v", expected, configuration)
+			}
+		})
+	}
+}
+
+func TestConfigurationWatcher(t *testing.T) {
+	path := writeConfiguration(t, "config.yaml", "auth_key: secret\nrate_limit: 1\nrate_limit_burst: 1\n")
+	configuration, err := LoadConfiguration(path)
+	if err != nil {
+		t.Fatal(err)
+	}
+	applied := make(chan Configuration, 1)
+	watcher := NewConfigurationWatcher(path, configuration, func(c Configuration) {
+		select {
+		case applied <- c:
+		default:
+		}
+	})
+	watcher.Watch(time.Hour)
+	defer watcher.Stop()
+
+	if err := os.WriteFile(path, []byte("auth_key: secret\nrate_limit: 1\nrate_limit_burst: 1\nport: nope\n"), 0o600); err != nil {
+		t.Fatal(err)
+	}
+	watcher.Reload()
+	if err := os.WriteFile(path, []byte("auth_key: secret\nrate_limit: 3\nrate_limit_burst: 1\nport: \"10001\"\n"), 0o600); err != nil {
+		t.Fatal(err)
+	}
+	watcher.Reload()
+
+	select {
+	case c := <-applied:
+		if c.RateLimit != 3 {
+			t.Errorf("expected rate limit 3, got %g", c.RateLimit)
+		}
+		if c.Port != configuration.Port {
+			t.Errorf("expected the running port %s, got %s", configuration.Port, c.Port)
+		}
+	case <-time.After(5 * time.Second):
+		t.Fatal("configuration was not reloaded")
+	}
+
+	if err := os.WriteFile(path, []byte("auth_key: secret\nrate_limit: 4\nrate_limit_burst: 1\nport: \"10001\"\n"), 0o600); err != nil {
+		t.Fatal(err)
+	}
+	watcher.Reload()
+	select {
+	case c := <-applied:
+		loaded, err := LoadConfiguration(path)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if settings := c.restartRequired(loaded); !reflect.DeepEqual(settings, []string{"port"}) {
+			t.Errorf("expected port to still require a restart, got %v", settings)
+		}
+	case <-time.After(5 * time.Second):
+		t.Fatal("configuration was not reloaded")
+	}
+}
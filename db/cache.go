@@ -0,0 +1,356 @@
+package db
+
+import (
+	"container/list"
+	"context"
+	"fmt"
+	"reflect"
+	"sync"
+	"sync/atomic"
+	"time"
+
+	"github.com/anchamber/genetics-tank/db/model"
+)
+
+// CacheOptions configures a CachedDB.
+type CacheOptions struct {
+	// Size is the maximum number of tanks and the maximum number of query
+	// results that are kept.
+	Size int
+	// TTL is how long query results are kept. Tanks are kept until they are
+	// written or evicted.
+	TTL time.Duration
+}
+
+// CacheStats counts the lookups of a CachedDB.
+type CacheStats struct {
+	TankHits    uint64
+	TankMisses  uint64
+	QueryHits   uint64
+	QueryMisses uint64
+	Evictions   uint64
+}
+
+// CachedDB is a read-through cache in front of another TankDB. Tanks read by
+// number are kept in an LRU keyed by number, results of Select and Count are
+// kept for a short time keyed by the normalized query.
+//
+// A write invalidates the cached tank with the written number and all query
+// results, as any write can change which tanks match a query. Results read
+// while a write was in progress are not cached.
+type CachedDB struct {
+	next TankDB
+	ttl  time.Duration
+	now  func() time.Time
+
+	mu      sync.Mutex
+	tanks   *lru[uint32, *model.Tank]
+	queries *lru[string, queryResult]
+	// generation is incremented by every write.
+	generation uint64
+
+	tankHits    atomic.Uint64
+	tankMisses  atomic.Uint64
+	queryHits   atomic.Uint64
+	queryMisses atomic.Uint64
+	evictions   atomic.Uint64
+}
+
+type queryResult struct {
+	tanks   []*model.Tank
+	count   uint64
+	expires time.Time
+}
+
+func NewCachedDB(next TankDB, options CacheOptions) *CachedDB {
+	c := &CachedDB{
+		next: next,
+		ttl:  options.TTL,
+		now:  time.Now,
+	}
+	c.tanks = newLRU[uint32, *model.Tank](options.Size, c.evicted)
+	c.queries = newLRU[string, queryResult](options.Size, c.evicted)
+	return c
+}
+
+func (c *CachedDB) evicted() {
+	c.evictions.Add(1)
+}
+
+// Stats returns the number of hits, misses and evictions so far.
+func (c *CachedDB) Stats() CacheStats {
+	return CacheStats{
+		TankHits:    c.tankHits.Load(),
+		TankMisses:  c.tankMisses.Load(),
+		QueryHits:   c.queryHits.Load(),
+		QueryMisses: c.queryMisses.Load(),
+		Evictions:   c.evictions.Load(),
+	}
+}
+
+func (c *CachedDB) Select(ctx context.Context, options Options) ([]*model.Tank, error) {
+	key, ok := selectKey(options)
+	if !ok {
+		return c.next.Select(ctx, options)
+	}
+	if result, ok := c.lookupQuery(key); ok {
+		return cloneTanks(result.tanks), nil
+	}
+	generation := c.currentGeneration()
+	tanks, err := c.next.Select(ctx, options)
+	if err != nil {
+		return nil, err
+	}
+	c.storeQuery(key, queryResult{tanks: cloneTanks(tanks)}, generation)
+	return tanks, nil
+}
+
+func (c *CachedDB) Count(ctx context.Context, options Options) (uint64, error) {
+	key, ok := countKey(options)
+	if !ok {
+		return c.next.Count(ctx, options)
+	}
+	if result, ok := c.lookupQuery(key); ok {
+		return result.count, nil
+	}
+	generation := c.currentGeneration()
+	count, err := c.next.Count(ctx, options)
+	if err != nil {
+		return 0, err
+	}
+	c.storeQuery(key, queryResult{count: count}, generation)
+	return count, nil
+}
+
+// SelectByNumber reads the tank with all columns on a miss, so one entry
+// serves every set of columns.
+func (c *CachedDB) SelectByNumber(ctx context.Context, number uint32, columns ...string) (*model.Tank, error) {
+	if err := validateColumns(columns); err != nil {
+		return nil, &Error{Op: "select", Number: number, Kind: ErrInvalidOptions, Err: err}
+	}
+	c.mu.Lock()
+	tank, ok := c.tanks.get(number)
+	c.mu.Unlock()
+	if ok {
+		c.tankHits.Add(1)
+		return project(tank, columns), nil
+	}
+	c.tankMisses.Add(1)
+
+	generation := c.currentGeneration()
+	tank, err := c.next.SelectByNumber(ctx, number)
+	if err != nil {
+		return nil, err
+	}
+	c.mu.Lock()
+	if c.generation == generation {
+		c.tanks.add(number, clone(tank))
+	}
+	c.mu.Unlock()
+	return project(tank, columns), nil
+}
+
+// Search is not cached.
+func (c *CachedDB) Search(ctx context.Context, query string, options Options) ([]*SearchResult, error) {
+	return c.next.Search(ctx, query, options)
+}
+
+func (c *CachedDB) Insert(ctx context.Context, tank *model.Tank) error {
+	err := c.next.Insert(ctx, tank)
+	c.invalidate(tank.Number)
+	return err
+}
+
+func (c *CachedDB) Update(ctx context.Context, tank *model.Tank) error {
+	err := c.next.Update(ctx, tank)
+	c.invalidate(tank.Number)
+	return err
+}
+
+func (c *CachedDB) Apply(ctx context.Context, number uint32, change Change) (*model.Tank, error) {
+	tank, err := c.next.Apply(ctx, number, change)
+	c.invalidate(number)
+	return tank, err
+}
+
+func (c *CachedDB) Delete(ctx context.Context, number uint32) error {
+	err := c.next.Delete(ctx, number)
+	c.invalidate(number)
+	return err
+}
+
+// CountBySystem is not cached, it is only read by metric scrapes.
+func (c *CachedDB) CountBySystem(ctx context.Context) ([]SystemCount, error) {
+	return c.next.CountBySystem(ctx)
+}
+
+func (c *CachedDB) Ping(ctx context.Context) error {
+	return c.next.Ping(ctx)
+}
+
+func (c *CachedDB) Close() error {
+	return c.next.Close()
+}
+
+// invalidate drops the tank with number and all query results after a write.
+// It is called even if the write failed, as a failed commit may still have
+// been applied.
+func (c *CachedDB) invalidate(number uint32) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	c.generation++
+	c.tanks.remove(number)
+	c.queries.clear()
+}
+
+func (c *CachedDB) currentGeneration() uint64 {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	return c.generation
+}
+
+func (c *CachedDB) lookupQuery(key string) (queryResult, bool) {
+	c.mu.Lock()
+	result, ok := c.queries.get(key)
+	if ok && !c.now().Before(result.expires) {
+		c.queries.remove(key)
+		ok = false
+	}
+	c.mu.Unlock()
+	if ok {
+		c.queryHits.Add(1)
+	} else {
+		c.queryMisses.Add(1)
+	}
+	return result, ok
+}
+
+// storeQuery caches result unless a write happened since generation.
+func (c *CachedDB) storeQuery(key string, result queryResult, generation uint64) {
+	if c.ttl <= 0 {
+		return
+	}
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	if c.generation != generation {
+		return
+	}
+	result.expires = c.now().Add(c.ttl)
+	c.queries.add(key, result)
+}
+
+// selectKey normalizes options to the statement they compile to together with
+// the values of its placeholders. Options that can't be compiled are not
+// cached.
+func selectKey(options Options) (string, bool) {
+	if options.validate() != nil {
+		return "", false
+	}
+	statement, values, err := options.createSelectStatement(options.selectColumns())
+	if err != nil {
+		return "", false
+	}
+	return fmt.Sprintf("select %s %#v", statement, values), true
+}
+
+func countKey(options Options) (string, bool) {
+	options.Pageination = nil
+	options.After = nil
+	clause, values, err := options.createFilterClause()
+	if err != nil {
+		return "", false
+	}
+	return fmt.Sprintf("count %s %#v", clause, values), true
+}
+
+func clone(tank *model.Tank) *model.Tank {
+	copied := *tank
+	return &copied
+}
+
+func cloneTanks(tanks []*model.Tank) []*model.Tank {
+	if tanks == nil {
+		return nil
+	}
+	cloned := make([]*model.Tank, len(tanks))
+	for i, tank := range tanks {
+		cloned[i] = clone(tank)
+	}
+	return cloned
+}
+
+// project returns a copy of tank with only the given columns set. All
+// columns are copied if none are given.
+func project(tank *model.Tank, columns []string) *model.Tank {
+	if len(columns) == 0 {
+		return clone(tank)
+	}
+	projected := &model.Tank{}
+	for _, column := range columns {
+		reflect.ValueOf(columnField(projected, column)).Elem().Set(reflect.ValueOf(columnField(tank, column)).Elem())
+	}
+	return projected
+}
+
+// lru is a map that evicts the least recently used entry once it holds more
+// than size entries. It is not safe for concurrent use.
+type lru[K comparable, V any] struct {
+	size    int
+	order   *list.List
+	entries map[K]*list.Element
+	evicted func()
+}
+
+type lruEntry[K comparable, V any] struct {
+	key   K
+	value V
+}
+
+func newLRU[K comparable, V any](size int, evicted func()) *lru[K, V] {
+	return &lru[K, V]{
+		size:    size,
+		order:   list.New(),
+		entries: make(map[K]*list.Element),
+		evicted: evicted,
+	}
+}
+
+func (l *lru[K, V]) get(key K) (V, bool) {
+	element, ok := l.entries[key]
+	if !ok {
+		var zero V
+		return zero, false
+	}
+	l.order.MoveToFront(element)
+	return element.Value.(*lruEntry[K, V]).value, true
+}
+
+func (l *lru[K, V]) add(key K, value V) {
+	if l.size <= 0 {
+		return
+	}
+	if element, ok := l.entries[key]; ok {
+		element.Value.(*lruEntry[K, V]).value = value
+		l.order.MoveToFront(element)
+		return
+	}
+	l.entries[key] = l.order.PushFront(&lruEntry[K, V]{key: key, value: value})
+	for l.order.Len() > l.size {
+		oldest := l.order.Back()
+		l.order.Remove(oldest)
+		delete(l.entries, oldest.Value.(*lruEntry[K, V]).key)
+		l.evicted()
+	}
+}
+
+func (l *lru[K, V]) remove(key K) {
+	if element, ok := l.entries[key]; ok {
+		l.order.Remove(element)
+		delete(l.entries, key)
+	}
+}
+
+func (l *lru[K, V]) clear() {
+	l.order.Init()
+	l.entries = make(map[K]*list.Element)
+}
@@ -0,0 +1,121 @@
+package db_test
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	apiModel "github.com/anchamber/genetics-api/model"
+	"github.com/anchamber/genetics-tank/db"
+	"github.com/anchamber/genetics-tank/db/model"
+)
+
+func newCachedDB(t *testing.T, ttl time.Duration) *db.CachedDB {
+	return db.NewCachedDB(newMockDB(t, []*model.Tank{
+		{System: "A", Number: 1, Active: true, Size: 10, FishCount: 5, Lab: "zebrafish"},
+		{System: "B", Number: 2, Active: false, Size: 20, FishCount: 0, Lab: "medaka"},
+	}), db.CacheOptions{Size: 2, TTL: ttl})
+}
+
+func TestCachedSelectByNumber(t *testing.T) {
+	ctx := context.Background()
+	cache := newCachedDB(t, time.Minute)
+
+	tank, err := cache.SelectByNumber(ctx, 1)
+	if err != nil {
+		t.Fatal(err)
+	}
+	tank.System = "changed by caller"
+	tank, err = cache.SelectByNumber(ctx, 1, "number", "size")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if tank.Size != 10 || tank.System != "" {
+		t.Errorf("expected only number and size of the cached tank, got %+v", tank)
+	}
+	if stats := cache.Stats(); stats.TankHits != 1 || stats.TankMisses != 1 {
+		t.Errorf("expected 1 hit and 1 miss, got %+v", stats)
+	}
+
+	if err := cache.Update(ctx, &model.Tank{System: "A", Number: 1, Size: 30}); err != nil {
+		t.Fatal(err)
+	}
+	tank, err = cache.SelectByNumber(ctx, 1)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if tank.Size != 30 {
+		t.Errorf("expected updated size 30, got %d", tank.Size)
+	}
+
+	if err := cache.Delete(ctx, 1); err != nil {
+		t.Fatal(err)
+	}
+	if _, err := cache.SelectByNumber(ctx, 1); !errors.Is(err, db.ErrNotFound) {
+		t.Errorf("expected deleted tank to be not found, got %v", err)
+	}
+	if _, err := cache.SelectByNumber(ctx, 2, "unknown"); !errors.Is(err, db.ErrInvalidOptions) {
+		t.Errorf("expected invalid column to be rejected, got %v", err)
+	}
+}
+
+func TestCachedQueries(t *testing.T) {
+	ctx := context.Background()
+	cache := newCachedDB(t, 50*time.Millisecond)
+	active := db.Options{Expression: db.Comparison{Key: "active", Operator: apiModel.EQ, Value: true}}
+
+	for i := 0; i < 2; i++ {
+		tanks, err := cache.Select(ctx, active)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if len(tanks) != 1 {
+			t.Fatalf("expected 1 active tank, got %d", len(tanks))
+		}
+		if count, err := cache.Count(ctx, active); err != nil || count != 1 {
+			t.Fatalf("expected count 1, got %d, %v", count, err)
+		}
+	}
+	if stats := cache.Stats(); stats.QueryHits != 2 || stats.QueryMisses != 2 {
+		t.Errorf("expected 2 hits and 2 misses, got %+v", stats)
+	}
+
+	if err := cache.Insert(ctx, &model.Tank{System: "C", Number: 3, Active: true, Size: 5}); err != nil {
+		t.Fatal(err)
+	}
+	if count, err := cache.Count(ctx, active); err != nil || count != 2 {
+		t.Errorf("expected count 2 after insert, got %d, %v", count, err)
+	}
+
+	time.Sleep(60 * time.Millisecond)
+	before := cache.Stats()
+	if _, err := cache.Count(ctx, active); err != nil {
+		t.Fatal(err)
+	}
+	if stats := cache.Stats(); stats.QueryMisses != before.QueryMisses+1 {
+		t.Errorf("expected expired result to be a miss, got %+v", stats)
+	}
+}
+
+func TestCacheEviction(t *testing.T) {
+	ctx := context.Background()
+	cache := newCachedDB(t, time.Minute)
+	for _, number := range []uint32{1, 2, 1} {
+		if _, err := cache.SelectByNumber(ctx, number); err != nil {
+			t.Fatal(err)
+		}
+	}
+	if err := cache.Insert(ctx, &model.Tank{System: "C", Number: 3, Size: 5}); err != nil {
+		t.Fatal(err)
+	}
+	for _, number := range []uint32{3, 1, 2} {
+		if _, err := cache.SelectByNumber(ctx, number); err != nil {
+			t.Fatal(err)
+		}
+	}
+	stats := cache.Stats()
+	if stats.TankHits != 2 || stats.TankMisses != 4 || stats.Evictions != 2 {
+		t.Errorf("expected 2 hits, 4 misses and 2 evictions, got %+v", stats)
+	}
+}
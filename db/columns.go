@@ -0,0 +1,88 @@
+package db
+
+import (
+	"fmt"
+
+	"github.com/anchamber/genetics-tank/db/model"
+)
+
+func isColumn(column string) bool {
+	for _, c := range Columns {
+		if c == column {
+			return true
+		}
+	}
+	return false
+}
+
+func validateColumns(columns []string) error {
+	for _, column := range columns {
+		if !isColumn(column) {
+			return fmt.Errorf("unknown column %q", column)
+		}
+	}
+	return nil
+}
+
+// selectColumns returns the columns to read for the options: the requested
+// columns and the ones of the sort order, without duplicates.
+func (o *Options) selectColumns() []string {
+	if len(o.Columns) == 0 {
+		return Columns
+	}
+	var columns []string
+	seen := make(map[string]bool)
+	add := func(column string) {
+		if !seen[column] {
+			seen[column] = true
+			columns = append(columns, column)
+		}
+	}
+	for _, column := range o.Columns {
+		add(column)
+	}
+	for _, order := range o.SortOrder() {
+		add(order.Key)
+	}
+	return columns
+}
+
+// tankFields returns pointers to the fields of tank to scan columns into.
+func tankFields(tank *model.Tank, columns []string) []interface{} {
+	fields := make([]interface{}, len(columns))
+	for i, column := range columns {
+		fields[i] = columnField(tank, column)
+	}
+	return fields
+}
+
+func columnField(tank *model.Tank, column string) interface{} {
+	switch column {
+	case "id":
+		return &tank.ID
+	case "system":
+		return &tank.System
+	case "number":
+		return &tank.Number
+	case "active":
+		return &tank.Active
+	case "size":
+		return &tank.Size
+	case "fish_count":
+		return &tank.FishCount
+	case "lab":
+		return &tank.Lab
+	case "line":
+		return &tank.Line
+	case "notes":
+		return &tank.Notes
+	case "responsible":
+		return &tank.Responsible
+	case "last_cleaned":
+		return &tank.LastCleaned
+	case "last_fed":
+		return &tank.LastFed
+	default:
+		return nil
+	}
+}
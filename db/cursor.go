@@ -0,0 +1,67 @@
+package db
+
+import (
+	"reflect"
+
+	apiModel "github.com/anchamber/genetics-api/model"
+	"github.com/anchamber/genetics-tank/db/model"
+)
+
+// Cursor is the position of a tank in the sort order of a query. Values
+// holds the value of every key of Options.SortOrder for the tank.
+type Cursor struct {
+	Values []interface{}
+}
+
+// SortOrder returns the keys tanks are sorted by: the keys of OrderBy
+// followed by number, if not sorted by number already, and id.
+func (o *Options) SortOrder() []Order {
+	order := make([]Order, 0, len(o.OrderBy)+2)
+	sortedByNumber := false
+	for _, key := range o.OrderBy {
+		order = append(order, key)
+		sortedByNumber = sortedByNumber || key.Key == "number"
+	}
+	if !sortedByNumber {
+		order = append(order, Order{Key: "number"})
+	}
+	return append(order, Order{Key: "id"})
+}
+
+// CursorOf returns the position of tank in the sort order of the options.
+func (o *Options) CursorOf(tank *model.Tank) *Cursor {
+	var values []interface{}
+	for _, order := range o.SortOrder() {
+		values = append(values, columnValue(tank, order.Key))
+	}
+	return &Cursor{Values: values}
+}
+
+func columnValue(tank *model.Tank, column string) interface{} {
+	field := columnField(tank, column)
+	if field == nil {
+		return nil
+	}
+	return reflect.ValueOf(field).Elem().Interface()
+}
+
+// createKeysetCondition matches the tanks following the cursor in the sort
+// order, i.e. (k1 > v1) OR (k1 = v1 AND k2 > v2) OR ... with < for keys
+// sorted in descending order.
+func (o *Options) createKeysetCondition() Expression {
+	order := o.SortOrder()
+	var alternatives Or
+	for i := range order {
+		var conditions And
+		for j := 0; j < i; j++ {
+			conditions = append(conditions, Comparison{Key: order[j].Key, Operator: apiModel.EQ, Value: o.After.Values[j]})
+		}
+		operator := apiModel.GREATER
+		if order[i].Descending {
+			operator = apiModel.SMALLER
+		}
+		conditions = append(conditions, Comparison{Key: order[i].Key, Operator: operator, Value: o.After.Values[i]})
+		alternatives = append(alternatives, conditions)
+	}
+	return alternatives
+}
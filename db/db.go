@@ -1,26 +1,131 @@
 package db
 
 import (
+	"context"
 	apiModel "github.com/anchamber/genetics-api/model"
 	"github.com/anchamber/genetics-tank/db/model"
 )
 
 type Options struct {
 	Pageination *apiModel.Pageination
-	Filters     []*apiModel.Filter
+	// Filters and Expression are combined using AND.
+	Filters    []*apiModel.Filter
+	Expression Expression
+	// OrderBy sorts the tanks. They are sorted by number and id after the
+	// given keys, so the order is always deterministic.
+	OrderBy []Order
+	// Columns limits the columns read from the database. Columns needed
+	// for the sort order are always read. If empty, all columns are read.
+	Columns []string
+	// After restricts the result to the tanks following the cursor in the
+	// sort order.
+	After *Cursor
+	Scope *Scope
 }
 
+// Order sorts tanks by the column Key.
+type Order struct {
+	Key        string
+	Descending bool
+}
+
+// Columns are the columns of the tanks table.
+var Columns = TankSchema.Columns()
+
+// SortKeys are the columns tanks can be sorted by.
+var SortKeys = Columns
+
+func IsSortKey(key string) bool {
+	for _, sortKey := range SortKeys {
+		if sortKey == key {
+			return true
+		}
+	}
+	return false
+}
+
+// Scope restricts the tanks visible to a caller to the ones in one of the
+// listed systems or owned by one of the listed labs.
+// A nil scope or one with All set is unrestricted, a scope without systems
+// and labs allows no tanks.
+type Scope struct {
+	All     bool
+	Systems []string
+	Labs    []string
+}
+
+func (s *Scope) Unrestricted() bool {
+	return s == nil || s.All
+}
+
+func (s *Scope) Allows(tank *model.Tank) bool {
+	if s.Unrestricted() {
+		return true
+	}
+	for _, system := range s.Systems {
+		if system == tank.System {
+			return true
+		}
+	}
+	for _, lab := range s.Labs {
+		if lab == tank.Lab {
+			return true
+		}
+	}
+	return false
+}
+
+// TankDB stores the tanks. Every operation is aborted when its context is
+// cancelled or its deadline is exceeded.
 type TankDB interface {
-	Select(Options) ([]*model.Tank, error)
-	SelectByNumber(number uint32) (*model.Tank, error)
-	Insert(tank *model.Tank) error
-	Update(tank *model.Tank) error
-	Delete(number uint32) error
+	Select(ctx context.Context, options Options) ([]*model.Tank, error)
+	// Count returns the number of tanks matching the options, ignoring
+	// pagination.
+	Count(ctx context.Context, options Options) (uint64, error)
+	SelectByNumber(ctx context.Context, number uint32, columns ...string) (*model.Tank, error)
+	// Search returns the tanks matching the words of query, best matches
+	// first. Filters, scope and the limit of the options are applied to the
+	// matches.
+	Search(ctx context.Context, query string, options Options) ([]*SearchResult, error)
+	Insert(ctx context.Context, tank *model.Tank) error
+	// Update overwrites the tank except for LastCleaned and LastFed, which
+	// are only changed by Apply.
+	Update(ctx context.Context, tank *model.Tank) error
+	// Apply makes change to the tank with the given number in a single
+	// statement and returns the changed tank.
+	Apply(ctx context.Context, number uint32, change Change) (*model.Tank, error)
+	Delete(ctx context.Context, number uint32) error
+	// CountBySystem aggregates the tanks by system in a single query.
+	CountBySystem(ctx context.Context) ([]SystemCount, error)
+	Ping(ctx context.Context) error
+	Close() error
+}
+
+// SystemCount aggregates the tanks of a system.
+type SystemCount struct {
+	System string
+	Tanks  uint64
+	Active uint64
+	Fish   uint64
 }
 
-type ErrorCode string
+// Change is an update of single fields of a tank that does not depend on
+// reading the tank first, so concurrent changes don't overwrite each other.
+type Change struct {
+	// LastCleaned and LastFed are set if they are not 0.
+	LastCleaned int64
+	LastFed     int64
+	// Mortality is subtracted from the fish count. The change fails with an
+	// error of kind ErrPrecondition if the tank has fewer fish.
+	Mortality uint32
+}
 
-const (
-	TankAlreadyExists ErrorCode = "tank already exists"
-	Unknown             ErrorCode = "unknown error with db occurred"
-)
+// SearchResult is a tank found by a full-text search.
+type SearchResult struct {
+	Tank *model.Tank
+	// Rank is lower for better matches.
+	Rank float64
+	// Snippet is an excerpt of the best matching column with the matching
+	// words enclosed in [ and ].
+	Snippet string
+}
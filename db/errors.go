@@ -0,0 +1,89 @@
+package db
+
+import (
+	"database/sql"
+	"errors"
+	"fmt"
+	"strings"
+
+	"github.com/mattn/go-sqlite3"
+)
+
+// The kinds of errors returned by a TankDB. Use errors.Is to check for them.
+var (
+	ErrNotFound       = errors.New("tank not found")
+	ErrConflict       = errors.New("tank already exists")
+	ErrConstraint     = errors.New("constraint violation")
+	ErrPrecondition   = errors.New("precondition failed")
+	ErrUnavailable    = errors.New("database unavailable")
+	ErrInvalidOptions = errors.New("invalid options")
+	ErrUnsupported    = errors.New("operation not supported")
+)
+
+// Error describes a failed TankDB operation.
+type Error struct {
+	// Op is the failed operation, e.g. "insert".
+	Op string
+	// Number of the affected tank or 0 if the operation affects several tanks.
+	Number uint32
+	// Kind is one of the Err* variables or nil if the cause is unknown.
+	Kind error
+	// Err is the underlying error, if any.
+	Err error
+}
+
+func (e *Error) Error() string {
+	msg := e.Op
+	if e.Number != 0 {
+		msg = fmt.Sprintf("%s tank %d", msg, e.Number)
+	}
+	switch {
+	case e.Kind != nil && e.Err != nil:
+		return fmt.Sprintf("%s: %v: %v", msg, e.Kind, e.Err)
+	case e.Kind != nil:
+		return fmt.Sprintf("%s: %v", msg, e.Kind)
+	case e.Err != nil:
+		return fmt.Sprintf("%s: %v", msg, e.Err)
+	default:
+		return msg + ": unknown error"
+	}
+}
+
+func (e *Error) Unwrap() []error {
+	var errs []error
+	if e.Kind != nil {
+		errs = append(errs, e.Kind)
+	}
+	if e.Err != nil {
+		errs = append(errs, e.Err)
+	}
+	return errs
+}
+
+// newError classifies err, which was returned by the database during op, as
+// one of the Err* kinds.
+func newError(op string, number uint32, err error) *Error {
+	e := &Error{Op: op, Number: number, Err: err}
+	var sqliteErr sqlite3.Error
+	switch {
+	case errors.As(err, &sqliteErr):
+		switch sqliteErr.Code {
+		case sqlite3.ErrConstraint:
+			e.Kind = ErrConstraint
+			if sqliteErr.ExtendedCode == sqlite3.ErrConstraintUnique || sqliteErr.ExtendedCode == sqlite3.ErrConstraintPrimaryKey {
+				e.Kind = ErrConflict
+			}
+		case sqlite3.ErrBusy, sqlite3.ErrLocked, sqlite3.ErrCantOpen, sqlite3.ErrIoErr:
+			e.Kind = ErrUnavailable
+		}
+	case errors.Is(err, sql.ErrConnDone), isClosed(err):
+		e.Kind = ErrUnavailable
+	}
+	return e
+}
+
+// isClosed reports whether err was returned because the database was closed.
+// database/sql doesn't export that error, so it is matched by its message.
+func isClosed(err error) bool {
+	return err != nil && strings.Contains(err.Error(), "sql: database is closed")
+}
@@ -0,0 +1,31 @@
+package db_test
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/anchamber/genetics-tank/db"
+	"github.com/anchamber/genetics-tank/db/model"
+)
+
+func TestClosedDatabase(t *testing.T) {
+	tankDB := newMockDB(t, []*model.Tank{{System: "A", Number: 1, Lab: "zebrafish"}})
+	if err := tankDB.Close(); err != nil {
+		t.Fatalf("failed to close database: %v", err)
+	}
+	ctx := context.Background()
+
+	_, err := tankDB.SelectByNumber(ctx, 1)
+	if !errors.Is(err, db.ErrUnavailable) {
+		t.Errorf("select after close, expected: %v | actual: %v", db.ErrUnavailable, err)
+	}
+	err = tankDB.Insert(ctx, &model.Tank{System: "A", Number: 2, Lab: "zebrafish"})
+	if !errors.Is(err, db.ErrUnavailable) {
+		t.Errorf("insert after close, expected: %v | actual: %v", db.ErrUnavailable, err)
+	}
+	_, err = tankDB.Apply(ctx, 1, db.Change{Mortality: 1})
+	if !errors.Is(err, db.ErrUnavailable) {
+		t.Errorf("apply after close, expected: %v | actual: %v", db.ErrUnavailable, err)
+	}
+}
@@ -0,0 +1,185 @@
+package db
+
+import (
+	"fmt"
+	"strings"
+
+	apiModel "github.com/anchamber/genetics-api/model"
+)
+
+// Expression is a boolean expression over the columns of the tanks table.
+// It is compiled to a parameterized SQL condition.
+type Expression interface {
+	compile(c *compiler) string
+}
+
+// And matches tanks matching all of its expressions. An empty And matches
+// every tank.
+type And []Expression
+
+// Or matches tanks matching any of its expressions. An empty Or matches no
+// tank.
+type Or []Expression
+
+// Not matches tanks not matching Expression.
+type Not struct {
+	Expression Expression
+}
+
+// Condition compares a column with a value.
+type Condition struct {
+	Filter *apiModel.Filter
+}
+
+// Comparison compares a column with a typed value.
+type Comparison struct {
+	Key      string
+	Operator apiModel.Operator
+	Value    interface{}
+}
+
+// In matches tanks where Key equals one of Values.
+type In struct {
+	Key    string
+	Values []interface{}
+}
+
+// Between matches tanks where Key is between Lower and Upper, inclusive.
+type Between struct {
+	Key   string
+	Lower interface{}
+	Upper interface{}
+}
+
+// IsNull matches tanks without a value for Key.
+type IsNull struct {
+	Key string
+}
+
+// compiler collects the values of the placeholders of a compiled expression
+// and makes sure every placeholder has a unique name. Values are only ever
+// bound to placeholders and keys are only used if they are columns of the
+// tanks table, so a compiled expression can't contain any SQL of the caller.
+type compiler struct {
+	values map[string]interface{}
+	err    error
+}
+
+func newCompiler() *compiler {
+	return &compiler{values: make(map[string]interface{})}
+}
+
+// bind returns a new placeholder for value.
+func (c *compiler) bind(value interface{}) string {
+	name := fmt.Sprintf("p%d", len(c.values))
+	c.values[name] = value
+	return ":" + name
+}
+
+// column returns key as quoted column. Unknown keys fail the compilation.
+func (c *compiler) column(key string) string {
+	column, err := quoteColumn(key)
+	if err != nil {
+		c.fail(err)
+		return "NULL"
+	}
+	return column
+}
+
+// comparison compiles the comparison of the column key with value.
+func (c *compiler) comparison(key string, operator apiModel.Operator, value interface{}) string {
+	column := c.column(key)
+	placeholder := c.bind(value)
+	if operator == apiModel.CONTAINS {
+		return fmt.Sprintf("(instr(%s, %s) > 0)", column, placeholder)
+	}
+	sqlOperator, err := operatorSQL(operator)
+	if err != nil {
+		c.fail(err)
+		return "0"
+	}
+	return fmt.Sprintf("(%s %s %s)", column, sqlOperator, placeholder)
+}
+
+// fail keeps the first error of the compilation.
+func (c *compiler) fail(err error) {
+	if c.err == nil {
+		c.err = err
+	}
+}
+
+func (e And) compile(c *compiler) string {
+	return join(c, e, " AND ", "1")
+}
+
+func (e Or) compile(c *compiler) string {
+	return join(c, e, " OR ", "0")
+}
+
+func join(c *compiler, expressions []Expression, separator string, empty string) string {
+	if len(expressions) == 0 {
+		return empty
+	}
+	conditions := make([]string, len(expressions))
+	for i, expression := range expressions {
+		conditions[i] = compile(c, expression)
+	}
+	return "(" + strings.Join(conditions, separator) + ")"
+}
+
+func (e Not) compile(c *compiler) string {
+	return "NOT " + compile(c, e.Expression)
+}
+
+func (e Condition) compile(c *compiler) string {
+	if e.Filter == nil {
+		c.fail(fmt.Errorf("condition without filter"))
+		return "0"
+	}
+	return c.comparison(e.Filter.Key, e.Filter.Operator, e.Filter.Value)
+}
+
+func (e Comparison) compile(c *compiler) string {
+	return c.comparison(e.Key, e.Operator, e.Value)
+}
+
+func (e In) compile(c *compiler) string {
+	column := c.column(e.Key)
+	if len(e.Values) == 0 {
+		return "0"
+	}
+	placeholders := make([]string, len(e.Values))
+	for i, value := range e.Values {
+		placeholders[i] = c.bind(value)
+	}
+	return fmt.Sprintf("(%s IN (%s))", column, strings.Join(placeholders, ", "))
+}
+
+func (e Between) compile(c *compiler) string {
+	return fmt.Sprintf("(%s BETWEEN %s AND %s)", c.column(e.Key), c.bind(e.Lower), c.bind(e.Upper))
+}
+
+func (e IsNull) compile(c *compiler) string {
+	return fmt.Sprintf("(%s IS NULL)", c.column(e.Key))
+}
+
+// compile compiles expression, failing for nil expressions.
+func compile(c *compiler, expression Expression) string {
+	if expression == nil {
+		c.fail(fmt.Errorf("empty expression"))
+		return "0"
+	}
+	return expression.compile(c)
+}
+
+// Compile returns expression as SQL condition together with the values of
+// its named placeholders. Keys that are not columns of the tanks table and
+// unknown operators are reported as error.
+func Compile(expression Expression) (string, map[string]interface{}, error) {
+	c := newCompiler()
+	sql := compile(c, expression)
+	if c.err != nil {
+		return "", nil, c.err
+	}
+	return sql, c.values, nil
+}
@@ -0,0 +1,82 @@
+package db_test
+
+import (
+	"reflect"
+	"testing"
+
+	apiModel "github.com/anchamber/genetics-api/model"
+	"github.com/anchamber/genetics-tank/db"
+)
+
+func TestCompile(t *testing.T) {
+	testCases := []struct {
+		name       string
+		expression db.Expression
+		sql        string
+		values     map[string]interface{}
+		err        bool
+	}{
+		{
+			name: "same key twice gets unique placeholders",
+			expression: db.Or{
+				db.Condition{Filter: &apiModel.Filter{Key: "system", Operator: apiModel.EQ, Value: "A"}},
+				db.Condition{Filter: &apiModel.Filter{Key: "system", Operator: apiModel.EQ, Value: "B"}},
+			},
+			sql:    `(("system" = :p0) OR ("system" = :p1))`,
+			values: map[string]interface{}{"p0": "A", "p1": "B"},
+		},
+		{
+			name: "nested expression",
+			expression: db.And{
+				db.Not{Expression: db.IsNull{Key: "lab"}},
+				db.In{Key: "system", Values: []interface{}{"A", "B"}},
+				db.Between{Key: "size", Lower: 5, Upper: 10},
+			},
+			sql:    `(NOT ("lab" IS NULL) AND ("system" IN (:p0, :p1)) AND ("size" BETWEEN :p2 AND :p3))`,
+			values: map[string]interface{}{"p0": "A", "p1": "B", "p2": 5, "p3": 10},
+		},
+		{
+			name:       "empty expressions",
+			expression: db.And{db.Or{}, db.In{Key: "system"}, db.And{}},
+			sql:        "(0 AND 0 AND 1)",
+			values:     map[string]interface{}{},
+		},
+		{
+			name:       "contains",
+			expression: db.Comparison{Key: "notes", Operator: apiModel.CONTAINS, Value: "x"},
+			sql:        `(instr("notes", :p0) > 0)`,
+			values:     map[string]interface{}{"p0": "x"},
+		},
+		{
+			name:       "unknown key",
+			expression: db.Or{db.IsNull{Key: "lab"}, db.IsNull{Key: "lab) OR (1"}},
+			err:        true,
+		},
+		{
+			name:       "unknown operator",
+			expression: db.Comparison{Key: "size", Operator: apiModel.Operator(42), Value: 1},
+			err:        true,
+		},
+		{
+			name:       "nil expression",
+			expression: db.Not{},
+			err:        true,
+		},
+	}
+
+	for _, tc := range testCases {
+		tc := tc
+		t.Run(tc.name, func(t *testing.T) {
+			sql, values, err := db.Compile(tc.expression)
+			if (err != nil) != tc.err {
+				t.Fatalf("unexpected error: %v", err)
+			}
+			if sql != tc.sql {
+				t.Errorf("wrong sql, expected: %s | actual: %s", tc.sql, sql)
+			}
+			if !reflect.DeepEqual(values, tc.values) {
+				t.Errorf("wrong values, expected: %v | actual: %v", tc.values, values)
+			}
+		})
+	}
+}
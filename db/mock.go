@@ -1,41 +1,52 @@
 package db
 
 import (
+	"context"
 	"database/sql"
 	"errors"
 	"fmt"
-	"log"
+	"log/slog"
+	"strings"
 
 	"github.com/jmoiron/sqlx"
-	"github.com/mattn/go-sqlite3"
 
-	apiModel "github.com/anchamber/genetics-api/model"
 	"github.com/anchamber/genetics-tank/db/model"
+	"github.com/anchamber/genetics-tank/logging"
 )
 
 type TankDBMock struct {
-	DB *sqlx.DB
+	DB     *sqlx.DB
+	logger *slog.Logger
 }
 
-var MockDataTanks = []*model.Tank{
-}
+var MockDataTanks = []*model.Tank{}
 
-func NewMockDB(initialData []*model.Tank) TankDBMock {
+// NewMockDB creates an in-memory database with the tables and initialData.
+func NewMockDB(initialData []*model.Tank, logger *slog.Logger) (TankDBMock, error) {
 	if initialData == nil {
 		initialData = MockDataTanks
 	}
+	if logger == nil {
+		logger = slog.Default()
+	}
+	db, err := initDB()
+	if err != nil {
+		return TankDBMock{}, err
+	}
 	mock := TankDBMock{
-		DB: initDB(),
+		DB:     db,
+		logger: logger,
 	}
 	mock.DB.SetMaxOpenConns(1)
 	for _, tank := range initialData {
-		err := mock.Insert(tank)
+		err := mock.Insert(context.Background(), tank)
 		if err != nil {
-			return TankDBMock{}
+			_ = db.Close()
+			return TankDBMock{}, fmt.Errorf("failed to insert tank %d: %w", tank.Number, err)
 		}
 	}
 
-	return mock
+	return mock, nil
 }
 
 func (o *Options) createPaginationClause() string {
@@ -49,258 +60,413 @@ func (o *Options) createPaginationClause() string {
 	return fmt.Sprintf("LIMIT %d OFFSET %d", limit, o.Pageination.Offset)
 }
 
-func getOperatorAsString(operator apiModel.Operator) string {
-	switch operator {
-	case apiModel.EQ:
-		return "="
-	case apiModel.GREATER:
-		return ">"
-	case apiModel.GREATER_EQ:
-		return ">="
-	case apiModel.SMALLER:
-		return "<"
-	case apiModel.SMALLER_EQ:
-		return "<="
-	case apiModel.CONTAINS:
-		return "LIKE"
-	default:
-		return "="
+// validate checks the options that can't be compiled to SQL.
+func (o *Options) validate() error {
+	for _, order := range o.OrderBy {
+		if !IsSortKey(order.Key) {
+			return fmt.Errorf("can not sort by %q", order.Key)
+		}
 	}
-}
-
-func (o *Options) createFilterClause() string {
-	if len(o.Filters) == 0 {
-		return ""
+	if err := validateColumns(o.Columns); err != nil {
+		return err
 	}
-	whereClause := "WHERE "
+	if o.After != nil && len(o.After.Values) != len(o.SortOrder()) {
+		return fmt.Errorf("cursor has %d values, sort order has %d keys", len(o.After.Values), len(o.SortOrder()))
+	}
+	return nil
+}
 
-	for index, filter := range o.Filters {
-		if index > 0 {
-			whereClause += " AND "
+func (o *Options) createOrderClause() (string, error) {
+	var orders []string
+	for _, order := range o.SortOrder() {
+		column, err := quoteColumn(order.Key)
+		if err != nil {
+			return "", err
 		}
-
-		if filter.Operator == apiModel.CONTAINS {
-			whereClause += fmt.Sprintf("instr(%s, :%s) > 0", filter.Key, filter.Key)
-		} else {
-			whereClause += fmt.Sprintf("%s %v :%s", filter.Key, getOperatorAsString(filter.Operator), filter.Key)
+		direction := "ASC"
+		if order.Descending {
+			direction = "DESC"
 		}
+		orders = append(orders, fmt.Sprintf("%s %s", column, direction))
 	}
-	// fmt.Println(whereClause)
-	return whereClause
+	return "ORDER BY " + strings.Join(orders, ", "), nil
 }
 
-func (o *Options) createFilterMap() map[string]interface{} {
-	values := make(map[string]interface{})
+// createFilterClause combines the filters, the expression and the scope of
+// the options into a WHERE clause and returns it with the values of its
+// placeholders.
+func (o *Options) createFilterClause() (string, map[string]interface{}, error) {
+	var conditions And
 	for _, filter := range o.Filters {
-		values[filter.Key] = filter.Value
+		conditions = append(conditions, Condition{Filter: filter})
+	}
+	if o.Expression != nil {
+		conditions = append(conditions, o.Expression)
+	}
+	if o.After != nil {
+		conditions = append(conditions, o.createKeysetCondition())
+	}
+	if !o.Scope.Unrestricted() {
+		conditions = append(conditions, o.createScopeCondition())
 	}
-	return values
+	if len(conditions) == 0 {
+		return "", nil, nil
+	}
+	clause, values, err := Compile(conditions)
+	if err != nil {
+		return "", nil, err
+	}
+	return "WHERE " + clause, values, nil
 }
 
-func (tankDB TankDBMock) Select(options Options) ([]*model.Tank, error) {
-	selectStatement := fmt.Sprintf("SELECT id, tank, number, active, size, fish_count FROM tanks %s %s;", options.createFilterClause(), options.createPaginationClause())
-	// fmt.Println(selectStatement)
-	filterValues := options.createFilterMap()
-	rows, err := tankDB.DB.NamedQuery(selectStatement, filterValues)
+// createSelectStatement builds the statement selecting the tanks matching
+// the options and returns it with the values of its placeholders.
+func (o *Options) createSelectStatement(columns []string) (string, map[string]interface{}, error) {
+	columnList, err := quoteColumns(columns)
+	if err != nil {
+		return "", nil, err
+	}
+	filterClause, values, err := o.createFilterClause()
+	if err != nil {
+		return "", nil, err
+	}
+	orderClause, err := o.createOrderClause()
 	if err != nil {
-		fmt.Printf("%v\n", err)
-		log.Fatalf(`failed to select all`)
-		return nil, err
+		return "", nil, err
+	}
+	return fmt.Sprintf("SELECT %s FROM tanks %s %s %s;", columnList, filterClause, orderClause, o.createPaginationClause()), values, nil
+}
+
+// createScopeCondition matches tanks in one of the systems or labs of the scope.
+func (o *Options) createScopeCondition() Expression {
+	var conditions Or
+	if len(o.Scope.Systems) > 0 {
+		conditions = append(conditions, In{Key: "system", Values: toValues(o.Scope.Systems)})
+	}
+	if len(o.Scope.Labs) > 0 {
+		conditions = append(conditions, In{Key: "lab", Values: toValues(o.Scope.Labs)})
+	}
+	return conditions
+}
+
+func toValues(values []string) []interface{} {
+	converted := make([]interface{}, len(values))
+	for i, value := range values {
+		converted[i] = value
+	}
+	return converted
+}
+
+func (tankDB TankDBMock) Select(ctx context.Context, options Options) ([]*model.Tank, error) {
+	if err := options.validate(); err != nil {
+		return nil, &Error{Op: "select", Kind: ErrInvalidOptions, Err: err}
+	}
+	columns := options.selectColumns()
+	selectStatement, filterValues, err := options.createSelectStatement(columns)
+	if err != nil {
+		return nil, &Error{Op: "select", Kind: ErrInvalidOptions, Err: err}
+	}
+	rows, err := tankDB.DB.NamedQueryContext(ctx, selectStatement, filterValues)
+	if err != nil {
+		logging.FromContext(ctx, tankDB.logger).Error("failed to select tanks", "filters", len(options.Filters), "error", err)
+		return nil, newError("select", 0, err)
 	}
 
 	defer func(rows *sqlx.Rows) {
 		err := rows.Close()
 		if err != nil {
-			fmt.Printf(fmt.Sprintf("failed closing rows %v\n", err))
+			logging.FromContext(ctx, tankDB.logger).Warn("failed closing rows", "error", err)
 		}
 	}(rows)
 	var data []*model.Tank
 	for rows.Next() {
 		var entry model.Tank
-		err = rows.Scan(&entry.ID, &entry.System, &entry.Number, &entry.Active, &entry.Size, &entry.FishCount)
+		err = rows.Scan(tankFields(&entry, columns)...)
 		if err != nil {
-			return nil, err
+			return nil, newError("select", 0, err)
 		}
 		data = append(data, &entry)
 	}
+	if err := rows.Err(); err != nil {
+		return nil, newError("select", 0, err)
+	}
 
 	return data, nil
 }
 
-func (tankDB TankDBMock) SelectByNumber(number uint32) (*model.Tank, error) {
+func (tankDB TankDBMock) Count(ctx context.Context, options Options) (uint64, error) {
+	options.Pageination = nil
+	options.After = nil
+	filterClause, filterValues, err := options.createFilterClause()
+	if err != nil {
+		return 0, &Error{Op: "count", Kind: ErrInvalidOptions, Err: err}
+	}
+	countStatement := fmt.Sprintf("SELECT COUNT(*) FROM tanks %s;", filterClause)
+	query, args, err := tankDB.DB.BindNamed(countStatement, filterValues)
+	if err != nil {
+		return 0, newError("count", 0, err)
+	}
+	var count uint64
+	err = tankDB.DB.QueryRowContext(ctx, query, args...).Scan(&count)
+	if err != nil {
+		logging.FromContext(ctx, tankDB.logger).Error("failed to count tanks", "filters", len(options.Filters), "error", err)
+		return 0, newError("count", 0, err)
+	}
+	return count, nil
+}
+
+// SelectByNumber returns the tank with the given number or an error of kind
+// ErrNotFound. If columns are given, only those columns are read.
+func (tankDB TankDBMock) SelectByNumber(ctx context.Context, number uint32, columns ...string) (*model.Tank, error) {
+	if len(columns) == 0 {
+		columns = Columns
+	}
+	columnList, err := quoteColumns(columns)
+	if err != nil {
+		return nil, &Error{Op: "select", Number: number, Kind: ErrInvalidOptions, Err: err}
+	}
 	//goland:noinspection ALL
-	selectStatement := `
-		SELECT tank, number, active, size, fish_count
+	selectStatement := fmt.Sprintf(`
+		SELECT %s
 		FROM tanks
 		WHERE number = $1;
-	`
-	rows, err := tankDB.DB.Query(selectStatement, number)
+	`, columnList)
+	rows, err := tankDB.DB.QueryContext(ctx, selectStatement, number)
 	if err != nil {
-		log.Fatalf(`failed to select all`)
-		return nil, err
-	}
-
-	var entry model.Tank
-	if !rows.Next() {
-		return nil, nil
+		logging.FromContext(ctx, tankDB.logger).Error("failed to select tank", "number", number, "error", err)
+		return nil, newError("select", number, err)
 	}
 	defer func(rows *sql.Rows) {
 		err := rows.Close()
 		if err != nil {
-			fmt.Printf(fmt.Sprintf("failed closing rows %v\n", err))
+			logging.FromContext(ctx, tankDB.logger).Warn("failed closing rows", "number", number, "error", err)
 		}
 	}(rows)
-	err = rows.Scan(&entry.ID, &entry.System, &entry.Number, &entry.Active, &entry.Size, &entry.FishCount)
+
+	var entry model.Tank
+	if !rows.Next() {
+		if err := rows.Err(); err != nil {
+			return nil, newError("select", number, err)
+		}
+		return nil, &Error{Op: "select", Number: number, Kind: ErrNotFound}
+	}
+	err = rows.Scan(tankFields(&entry, columns)...)
 	if err != nil {
-		return nil, err
+		return nil, newError("select", number, err)
 	}
 
 	return &entry, nil
 }
 
-func (tankDB TankDBMock) Insert(tank *model.Tank) error {
-	var errorString = ""
+// Insert creates tank or returns an error of kind ErrConflict if a tank with
+// the same number exists.
+func (tankDB TankDBMock) Insert(ctx context.Context, tank *model.Tank) error {
 	//goland:noinspection ALL
 	insertStatement := `
-		INSERT INTO tanks (tank, number, active, size, fish_count)
-			VALUES (?, ?, ?, ?, ?, ?);
+		INSERT INTO tanks (system, number, active, size, fish_count, lab, line, notes, responsible, last_cleaned, last_fed)
+			VALUES (?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?);
 	`
-	tx, err := tankDB.DB.Begin()
-	if err != nil {
-		fmt.Printf("failed to begin transaction\n")
-		return err
+	return tankDB.exec(ctx, "insert", tank.Number, insertStatement, tank.System, tank.Number, tank.Active, tank.Size, tank.FishCount, tank.Lab, tank.Line, tank.Notes, tank.Responsible, tank.LastCleaned, tank.LastFed)
+}
+
+// Update overwrites the tank with the number of tank or returns an error of
+// kind ErrNotFound if there is no such tank. LastCleaned and LastFed are only
+// changed by Apply.
+func (tankDB TankDBMock) Update(ctx context.Context, tank *model.Tank) error {
+	//goland:noinspection ALL
+	updateStatement := `
+		UPDATE tanks 
+			SET system = ?, active = ?, size = ?, fish_count = ?, lab = ?, line = ?, notes = ?, responsible = ?
+			WHERE number = ?;
+	`
+	return tankDB.exec(ctx, "update", tank.Number, updateStatement, tank.System, tank.Active, tank.Size, tank.FishCount, tank.Lab, tank.Line, tank.Notes, tank.Responsible, tank.Number)
+}
+
+// Apply makes change to the tank with the given number in a single UPDATE
+// and reads the changed tank in the same transaction. It returns an error of
+// kind ErrNotFound if there is no such tank and of kind ErrPrecondition if
+// the tank has fewer fish than the mortality of change.
+func (tankDB TankDBMock) Apply(ctx context.Context, number uint32, change Change) (*model.Tank, error) {
+	var assignments []string
+	var args []interface{}
+	if change.LastCleaned != 0 {
+		assignments = append(assignments, "last_cleaned = ?")
+		args = append(args, change.LastCleaned)
+	}
+	if change.LastFed != 0 {
+		assignments = append(assignments, "last_fed = ?")
+		args = append(args, change.LastFed)
 	}
+	if change.Mortality != 0 {
+		assignments = append(assignments, "fish_count = fish_count - ?")
+		args = append(args, change.Mortality)
+	}
+	if len(assignments) == 0 {
+		return nil, &Error{Op: "apply", Number: number, Kind: ErrInvalidOptions, Err: errors.New("empty change")}
+	}
+	args = append(args, number, change.Mortality)
+	//goland:noinspection ALL
+	updateStatement := fmt.Sprintf(`
+		UPDATE tanks
+			SET %s
+			WHERE number = ? AND fish_count >= ?;
+	`, strings.Join(assignments, ", "))
 
-	statement, err := tx.Prepare(insertStatement)
+	tx, err := tankDB.DB.BeginTx(ctx, nil)
 	if err != nil {
-		fmt.Printf("failed to prepare statement\n")
-		return err
+		logging.FromContext(ctx, tankDB.logger).Error("failed to begin transaction", "number", number, "error", err)
+		return nil, newError("apply", number, err)
 	}
-	defer func(statement *sql.Stmt) {
-		err := statement.Close()
-		if err != nil {
-			fmt.Printf(fmt.Sprintf("failed closing statement %v\n", err))
+	defer func(tx *sql.Tx) {
+		err := tx.Rollback()
+		if err != nil && !errors.Is(err, sql.ErrTxDone) {
+			logging.FromContext(ctx, tankDB.logger).Warn("failed rolling back transaction", "number", number, "error", err)
 		}
-	}(statement)
+	}(tx)
 
-	_, err = statement.Exec(tank.ID, tank.System, tank.Number, tank.Active, tank.Size, tank.FishCount)
+	result, err := tx.ExecContext(ctx, updateStatement, args...)
 	if err != nil {
-		fmt.Printf("failed to execute statement\n")
-		if sqliteErr, ok := err.(sqlite3.Error); ok {
-			switch sqliteErr.Code {
-			case sqlite3.ErrConstraint:
-				errorString = string(TankAlreadyExists)
-			default:
-				fmt.Printf("%v\n", sqliteErr)
-				errorString = string(Unknown)
-			}
-		} else {
-			fmt.Printf("%v\n", err.Error())
-			errorString = string(Unknown)
-		}
-		err := tx.Rollback()
-		if err != nil {
-			return err
-		}
-	} else {
-		// numberCreated, _ := result.RowsAffected()
-		// fmt.Printf("created %d entries\n", numberCreated)
-		err := tx.Commit()
-		if err != nil {
-			return err
-		}
+		logging.FromContext(ctx, tankDB.logger).Error("failed to apply change", "number", number, "error", err)
+		return nil, newError("apply", number, err)
+	}
+	affected, err := result.RowsAffected()
+	if err != nil {
+		return nil, newError("apply", number, err)
+	}
+
+	columnList, err := quoteColumns(Columns)
+	if err != nil {
+		return nil, &Error{Op: "apply", Number: number, Kind: ErrInvalidOptions, Err: err}
 	}
-	if errorString == "" {
-		return nil
+	var tank model.Tank
+	err = tx.QueryRowContext(ctx, fmt.Sprintf("SELECT %s FROM tanks WHERE number = ?;", columnList), number).Scan(tankFields(&tank, Columns)...)
+	if errors.Is(err, sql.ErrNoRows) {
+		return nil, &Error{Op: "apply", Number: number, Kind: ErrNotFound}
 	}
-	return errors.New(errorString)
+	if err != nil {
+		return nil, newError("apply", number, err)
+	}
+	if affected == 0 {
+		return nil, &Error{Op: "apply", Number: number, Kind: ErrPrecondition,
+			Err: fmt.Errorf("tank has %d fish, can't remove %d", tank.FishCount, change.Mortality)}
+	}
+	if err := tx.Commit(); err != nil {
+		return nil, newError("apply", number, err)
+	}
+	return &tank, nil
 }
 
-func (tankDB TankDBMock) Update(tank *model.Tank) error {
+// Delete removes the tank with the given number or returns an error of kind
+// ErrNotFound if there is no such tank.
+func (tankDB TankDBMock) Delete(ctx context.Context, number uint32) error {
 	//goland:noinspection ALL
-	insertStatement := `
-		UPDATE tanks 
-			SET tank = $1, number = $2, active = $3, size = $4, fish_count = $5
-			WHERE name = $1;
+	deleteStatement := `
+		DELETE FROM tanks WHERE number = ?;
 	`
-	tx, err := tankDB.DB.Begin()
+	return tankDB.exec(ctx, "delete", number, deleteStatement, number)
+}
+
+// exec runs statement for the tank with the given number in a transaction.
+// The statement has to affect exactly one row, otherwise it is rolled back
+// and an error of kind ErrNotFound is returned.
+func (tankDB TankDBMock) exec(ctx context.Context, op string, number uint32, statementString string, args ...interface{}) error {
+	tx, err := tankDB.DB.BeginTx(ctx, nil)
 	if err != nil {
-		fmt.Printf("failed to begin transaction\n")
-		return err
+		logging.FromContext(ctx, tankDB.logger).Error("failed to begin transaction", "number", number, "error", err)
+		return newError(op, number, err)
 	}
+	defer func(tx *sql.Tx) {
+		err := tx.Rollback()
+		if err != nil && !errors.Is(err, sql.ErrTxDone) {
+			logging.FromContext(ctx, tankDB.logger).Warn("failed rolling back transaction", "number", number, "error", err)
+		}
+	}(tx)
 
-	statement, err := tx.Prepare(insertStatement)
+	statement, err := tx.PrepareContext(ctx, statementString)
 	if err != nil {
-		fmt.Printf("failed to prepare statement\n")
-		return err
+		logging.FromContext(ctx, tankDB.logger).Error("failed to prepare statement", "number", number, "error", err)
+		return newError(op, number, err)
 	}
 	defer func(statement *sql.Stmt) {
 		err := statement.Close()
 		if err != nil {
-			fmt.Printf(fmt.Sprintf("failed closing statement %v\n", err))
+			logging.FromContext(ctx, tankDB.logger).Warn("failed closing statement", "number", number, "error", err)
 		}
 	}(statement)
 
-	_, err = statement.Exec(tank.ID, tank.System, tank.Number, tank.Active, tank.Size, tank.FishCount)
+	result, err := statement.ExecContext(ctx, args...)
 	if err != nil {
-		fmt.Printf("failed to execute statement\n")
-		return err
+		logging.FromContext(ctx, tankDB.logger).Error("failed to execute statement", "number", number, "error", err)
+		return newError(op, number, err)
+	}
+	affected, err := result.RowsAffected()
+	if err != nil {
+		return newError(op, number, err)
+	}
+	if affected == 0 {
+		return &Error{Op: op, Number: number, Kind: ErrNotFound}
 	}
 	err = tx.Commit()
 	if err != nil {
-		return err
+		return newError(op, number, err)
 	}
 	return nil
 }
 
-func (tankDB TankDBMock) Delete(number uint32) error {
+func (tankDB TankDBMock) CountBySystem(ctx context.Context) ([]SystemCount, error) {
 	//goland:noinspection ALL
-	statementString := `
-		DELETE FROM tanks WHERE number = ?;
+	countStatement := `
+		SELECT COALESCE(system, ''), COUNT(*), COALESCE(SUM(active), 0), COALESCE(SUM(fish_count), 0)
+		FROM tanks
+		GROUP BY system;
 	`
-	statement, err := tankDB.DB.Prepare(statementString)
+	rows, err := tankDB.DB.QueryContext(ctx, countStatement)
 	if err != nil {
-		fmt.Printf("failed to prepare statement\n")
-		return err
+		logging.FromContext(ctx, tankDB.logger).Error("failed to aggregate tanks", "error", err)
+		return nil, newError("count_by_system", 0, err)
 	}
-	defer func(statement *sql.Stmt) {
-		err := statement.Close()
+	defer func(rows *sql.Rows) {
+		err := rows.Close()
 		if err != nil {
-			fmt.Printf(fmt.Sprintf("failed closing statement %v\n", err))
+			logging.FromContext(ctx, tankDB.logger).Warn("failed closing rows", "error", err)
 		}
-	}(statement)
-
-	_, err = statement.Exec(number)
-	if err != nil {
-		fmt.Printf("failed to execute statement\n")
-		if sqliteErr, ok := err.(sqlite3.Error); ok {
-			switch sqliteErr.Code {
-			case sqlite3.ErrConstraint:
-			default:
-			}
-		} else {
-			fmt.Printf("%v\n", err.Error())
-			return errors.New(string(Unknown))
+	}(rows)
+	var counts []SystemCount
+	for rows.Next() {
+		var entry SystemCount
+		if err := rows.Scan(&entry.System, &entry.Tanks, &entry.Active, &entry.Fish); err != nil {
+			return nil, newError("count_by_system", 0, err)
 		}
+		counts = append(counts, entry)
 	}
-	return nil
+	if err := rows.Err(); err != nil {
+		return nil, newError("count_by_system", 0, err)
+	}
+	return counts, nil
 }
 
-func initDB() *sqlx.DB {
+func (tankDB TankDBMock) Ping(ctx context.Context) error {
+	return tankDB.DB.PingContext(ctx)
+}
+
+// Close waits for running queries to finish and closes the database.
+func (tankDB TankDBMock) Close() error {
+	return tankDB.DB.Close()
+}
+
+func initDB() (*sqlx.DB, error) {
 	db, err := sqlx.Connect("sqlite3", ":memory:")
 	if err != nil {
-		panic(err)
+		return nil, fmt.Errorf("failed to open database: %w", err)
 	}
 
 	err = CreateTables(db)
 	if err != nil {
-		return nil
-	}
-	if err != nil {
-		return nil
+		_ = db.Close()
+		return nil, fmt.Errorf("failed to create tables: %w", err)
 	}
 
-	return db
+	return db, nil
 }
 
 func CreateTables(db *sqlx.DB) error {
@@ -309,17 +475,22 @@ func CreateTables(db *sqlx.DB) error {
 		CREATE TABLE IF NOT EXISTS tanks(
 			id					INTEGER	PRIMARY KEY AUTOINCREMENT,
 			number				INT UNIQUE,
-			tank				string,
+			system				string,
 			active				bit ,
 			size				INT,
-			fish_count 			INT
+			fish_count 			INT,
+			lab					string,
+			line				string,
+			notes				string,
+			responsible			string,
+			last_cleaned		INT DEFAULT 0,
+			last_fed			INT DEFAULT 0
 		);
 	`
 
 	_, err := db.Exec(tankTable)
 	if err != nil {
-		log.Fatalf("failed to create table\n")
-		return err
+		return fmt.Errorf("failed to create table: %w", err)
 	}
-	return nil
+	return createSearchTables(db)
 }
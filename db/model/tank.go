@@ -1,10 +1,17 @@
 package model
 
 type Tank struct {
-	ID        int64  `db:"id"`
-	System    string `db:"system"`
-	Number    uint32 `db:"number"`
-	Active    bool   `db:"active"`
-	Size      uint32 `db:"size"`
-	FishCount uint32 `db:"fish_count"`
+	ID          int64  `db:"id"`
+	System      string `db:"system"`
+	Number      uint32 `db:"number"`
+	Active      bool   `db:"active"`
+	Size        uint32 `db:"size"`
+	FishCount   uint32 `db:"fish_count"`
+	Lab         string `db:"lab"`
+	Line        string `db:"line"`
+	Notes       string `db:"notes"`
+	Responsible string `db:"responsible"`
+	// LastCleaned and LastFed are Unix times in seconds, 0 if unknown.
+	LastCleaned int64 `db:"last_cleaned"`
+	LastFed     int64 `db:"last_fed"`
 }
@@ -0,0 +1,55 @@
+package db
+
+import (
+	"fmt"
+	"strings"
+
+	apiModel "github.com/anchamber/genetics-api/model"
+)
+
+// quoteIdentifier quotes name so it is always read as an identifier by
+// SQLite, whatever characters it contains.
+func quoteIdentifier(name string) string {
+	return `"` + strings.ReplaceAll(name, `"`, `""`) + `"`
+}
+
+// quoteColumn returns the quoted column or an error if column is not a column
+// of the tanks table. Only columns of the allowlist ever make it into a
+// statement.
+func quoteColumn(column string) (string, error) {
+	if !isColumn(column) {
+		return "", fmt.Errorf("unknown column %q", column)
+	}
+	return quoteIdentifier(column), nil
+}
+
+// quoteColumns returns the quoted columns separated by commas.
+func quoteColumns(columns []string) (string, error) {
+	quoted := make([]string, len(columns))
+	for i, column := range columns {
+		var err error
+		if quoted[i], err = quoteColumn(column); err != nil {
+			return "", err
+		}
+	}
+	return strings.Join(quoted, ", "), nil
+}
+
+// operatorSQL returns the SQL operator of a comparison. CONTAINS has no
+// operator, it is compiled to instr.
+func operatorSQL(operator apiModel.Operator) (string, error) {
+	switch operator {
+	case apiModel.EQ:
+		return "=", nil
+	case apiModel.GREATER:
+		return ">", nil
+	case apiModel.GREATER_EQ:
+		return ">=", nil
+	case apiModel.SMALLER:
+		return "<", nil
+	case apiModel.SMALLER_EQ:
+		return "<=", nil
+	default:
+		return "", fmt.Errorf("unknown operator %d", operator)
+	}
+}
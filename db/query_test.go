@@ -0,0 +1,119 @@
+package db_test
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	apiModel "github.com/anchamber/genetics-api/model"
+	"github.com/anchamber/genetics-tank/db"
+	"github.com/anchamber/genetics-tank/db/model"
+)
+
+var fuzzData = []*model.Tank{
+	{System: "A", Number: 1, Active: true, Size: 10, FishCount: 5, Lab: "zebrafish"},
+	{System: "B", Number: 2, Active: false, Size: 20, FishCount: 0, Lab: "medaka"},
+}
+
+func newMockDB(t testing.TB, tanks []*model.Tank) db.TankDBMock {
+	t.Helper()
+	tankDB, err := db.NewMockDB(tanks, nil)
+	if err != nil {
+		t.Fatalf("failed to create database: %v", err)
+	}
+	return tankDB
+}
+
+var injections = []string{
+	"system",
+	"number; DROP TABLE tanks",
+	"1=1) OR (1=1",
+	`system" OR "1"="1`,
+	"system --",
+	"' OR ''='",
+	"`system`",
+	"system\x00",
+	"(SELECT COUNT(*) FROM tanks)",
+	"",
+}
+
+// FuzzSelect selects tanks with arbitrary keys and values in every part of
+// the options. The query must either be rejected as invalid or run without
+// changing the table or matching more tanks than a literal comparison could.
+func FuzzSelect(f *testing.F) {
+	for _, injection := range injections {
+		f.Add(injection, injection)
+		f.Add("system", injection)
+	}
+	tankDB := newMockDB(f, fuzzData)
+	ctx := context.Background()
+	f.Fuzz(func(t *testing.T, key string, value string) {
+		optionsList := []db.Options{
+			{Filters: []*apiModel.Filter{{Key: key, Operator: apiModel.EQ, Value: value}}},
+			{Filters: []*apiModel.Filter{{Key: key, Operator: apiModel.CONTAINS, Value: value}}},
+			{Expression: db.Or{db.Comparison{Key: key, Operator: apiModel.GREATER, Value: value}}},
+			{Expression: db.In{Key: key, Values: []interface{}{value}}},
+			{Expression: db.Between{Key: key, Lower: value, Upper: value}},
+			{Expression: db.Not{Expression: db.IsNull{Key: key}}},
+			{OrderBy: []db.Order{{Key: key}}},
+			{Columns: []string{key}},
+			{Scope: &db.Scope{Systems: []string{value}, Labs: []string{key}}},
+		}
+		for _, options := range optionsList {
+			tanks, err := tankDB.Select(ctx, options)
+			if err != nil {
+				if !errors.Is(err, db.ErrInvalidOptions) {
+					t.Fatalf("unexpected error for key %q and value %q: %v", key, value, err)
+				}
+				continue
+			}
+			if options.Scope != nil && len(tanks) > 0 && value != "A" && value != "B" && key != "zebrafish" && key != "medaka" {
+				t.Fatalf("scope %q, %q escaped: %d tanks", value, key, len(tanks))
+			}
+			if _, err := tankDB.Count(ctx, options); err != nil && !errors.Is(err, db.ErrInvalidOptions) {
+				t.Fatalf("unexpected count error for key %q and value %q: %v", key, value, err)
+			}
+		}
+		if _, err := tankDB.SelectByNumber(ctx, 1, key); err != nil && !errors.Is(err, db.ErrInvalidOptions) {
+			t.Fatalf("unexpected error selecting column %q: %v", key, err)
+		}
+		count, err := tankDB.Count(ctx, db.Options{})
+		if err != nil || count != uint64(len(fuzzData)) {
+			t.Fatalf("table changed, count: %d, error: %v", count, err)
+		}
+	})
+}
+
+// FuzzCompile makes sure keys that are not columns never reach the compiled
+// SQL and values are always bound to placeholders.
+func FuzzCompile(f *testing.F) {
+	for _, injection := range injections {
+		f.Add(injection, injection)
+	}
+	f.Fuzz(func(t *testing.T, key string, value string) {
+		sql, values, err := db.Compile(db.And{
+			db.Comparison{Key: key, Operator: apiModel.EQ, Value: value},
+			db.In{Key: key, Values: []interface{}{value}},
+		})
+		isColumn := false
+		for _, column := range db.Columns {
+			isColumn = isColumn || column == key
+		}
+		if !isColumn {
+			if err == nil {
+				t.Fatalf("key %q compiled to %s", key, sql)
+			}
+			return
+		}
+		if err != nil {
+			t.Fatalf("column %q was rejected: %v", key, err)
+		}
+		expected := `(("` + key + `" = :p0) AND ("` + key + `" IN (:p1)))`
+		if sql != expected {
+			t.Fatalf("expected %s, got %s", expected, sql)
+		}
+		if values["p0"] != value || values["p1"] != value {
+			t.Fatalf("values not bound: %v", values)
+		}
+	})
+}
@@ -0,0 +1,142 @@
+package db
+
+import (
+	"fmt"
+	"reflect"
+	"strconv"
+
+	apiModel "github.com/anchamber/genetics-api/model"
+	"github.com/anchamber/genetics-tank/db/model"
+)
+
+// FieldType is the type of the values of a field.
+type FieldType int
+
+const (
+	String FieldType = iota
+	Number
+	Bool
+)
+
+func (t FieldType) String() string {
+	switch t {
+	case Number:
+		return "number"
+	case Bool:
+		return "boolean"
+	default:
+		return "string"
+	}
+}
+
+// Field describes a field of a tank that can be filtered by.
+type Field struct {
+	// Key is the name of the field in filters.
+	Key string
+	// Column is the column of the field in the tanks table.
+	Column    string
+	Type      FieldType
+	Operators []apiModel.Operator
+	kind      reflect.Kind
+}
+
+// Ordered reports whether the values of the field can be compared with
+// < and >.
+func (f *Field) Ordered() bool {
+	return f.Type != Bool
+}
+
+// Allows reports whether operator can be used to filter by the field.
+func (f *Field) Allows(operator apiModel.Operator) bool {
+	for _, o := range f.Operators {
+		if o == operator {
+			return true
+		}
+	}
+	return false
+}
+
+// Coerce converts value into the type of the field, e.g. "true" into a bool
+// for a boolean field.
+func (f *Field) Coerce(value string) (interface{}, error) {
+	switch f.kind {
+	case reflect.Bool:
+		b, err := strconv.ParseBool(value)
+		if err != nil {
+			return nil, fmt.Errorf("%q is not a boolean", value)
+		}
+		return b, nil
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64:
+		i, err := strconv.ParseInt(value, 10, 64)
+		if err != nil {
+			return nil, fmt.Errorf("%q is not an integer", value)
+		}
+		return i, nil
+	case reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64:
+		u, err := strconv.ParseUint(value, 10, 64)
+		if err != nil {
+			return nil, fmt.Errorf("%q is not a non-negative integer", value)
+		}
+		return u, nil
+	default:
+		return value, nil
+	}
+}
+
+// Schema lists the fields tanks can be filtered by.
+type Schema struct {
+	fields []*Field
+	byKey  map[string]*Field
+}
+
+// TankSchema is derived from the db tags of model.Tank.
+var TankSchema = newSchema(reflect.TypeOf(model.Tank{}))
+
+func newSchema(t reflect.Type) *Schema {
+	schema := &Schema{byKey: make(map[string]*Field)}
+	for i := 0; i < t.NumField(); i++ {
+		structField := t.Field(i)
+		column := structField.Tag.Get("db")
+		if column == "" || column == "-" {
+			continue
+		}
+		field := &Field{Key: column, Column: column, kind: structField.Type.Kind()}
+		switch field.kind {
+		case reflect.Bool:
+			field.Type = Bool
+			field.Operators = []apiModel.Operator{apiModel.EQ}
+		case reflect.String:
+			field.Type = String
+			field.Operators = []apiModel.Operator{apiModel.EQ, apiModel.CONTAINS}
+		default:
+			field.Type = Number
+			field.Operators = []apiModel.Operator{apiModel.EQ, apiModel.GREATER, apiModel.GREATER_EQ, apiModel.SMALLER, apiModel.SMALLER_EQ}
+		}
+		schema.fields = append(schema.fields, field)
+		schema.byKey[field.Key] = field
+	}
+	return schema
+}
+
+// Field returns the field with the given key or nil if there is none.
+func (s *Schema) Field(key string) *Field {
+	return s.byKey[key]
+}
+
+// Keys returns the keys of all fields.
+func (s *Schema) Keys() []string {
+	keys := make([]string, len(s.fields))
+	for i, field := range s.fields {
+		keys[i] = field.Key
+	}
+	return keys
+}
+
+// Columns returns the columns of all fields.
+func (s *Schema) Columns() []string {
+	columns := make([]string, len(s.fields))
+	for i, field := range s.fields {
+		columns[i] = field.Column
+	}
+	return columns
+}
@@ -0,0 +1,46 @@
+package db_test
+
+import (
+	"testing"
+
+	apiModel "github.com/anchamber/genetics-api/model"
+	"github.com/anchamber/genetics-tank/db"
+)
+
+func TestTankSchema(t *testing.T) {
+	testCases := []struct {
+		key      string
+		value    string
+		operator apiModel.Operator
+		allowed  bool
+		expected interface{}
+		invalid  bool
+	}{
+		{key: "system", value: "A", operator: apiModel.CONTAINS, allowed: true, expected: "A"},
+		{key: "active", value: "true", operator: apiModel.EQ, allowed: true, expected: true},
+		{key: "active", value: "yes", operator: apiModel.GREATER, allowed: false, invalid: true},
+		{key: "number", value: "12", operator: apiModel.SMALLER_EQ, allowed: true, expected: uint64(12)},
+		{key: "number", value: "-1", operator: apiModel.CONTAINS, allowed: false, invalid: true},
+		{key: "id", value: "-1", operator: apiModel.GREATER, allowed: true, expected: int64(-1)},
+	}
+	for _, tc := range testCases {
+		field := db.TankSchema.Field(tc.key)
+		if field == nil {
+			t.Fatalf("no field for key %q", tc.key)
+		}
+		if field.Allows(tc.operator) != tc.allowed {
+			t.Errorf("%s allows %d: expected %t", tc.key, tc.operator, tc.allowed)
+		}
+		value, err := field.Coerce(tc.value)
+		if (err != nil) != tc.invalid {
+			t.Errorf("coerce %q for %s: unexpected error %v", tc.value, tc.key, err)
+			continue
+		}
+		if err == nil && value != tc.expected {
+			t.Errorf("coerce %q for %s: expected %#v, got %#v", tc.value, tc.key, tc.expected, value)
+		}
+	}
+	if db.TankSchema.Field("name") != nil {
+		t.Error("schema contains field name that is not part of the tank model")
+	}
+}
@@ -0,0 +1,14 @@
+package db
+
+import "strings"
+
+// matchQuery converts the words of text into an FTS5 query matching rows
+// that contain every word as prefix of one of their words. Every word is
+// quoted, so text can't use the FTS5 query syntax.
+func matchQuery(text string) string {
+	words := strings.Fields(text)
+	for i, word := range words {
+		words[i] = `"` + strings.ReplaceAll(word, `"`, `""`) + `"*`
+	}
+	return strings.Join(words, " ")
+}
@@ -0,0 +1,106 @@
+//go:build sqlite_fts5
+
+package db
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/anchamber/genetics-tank/db/model"
+	"github.com/anchamber/genetics-tank/logging"
+	"github.com/jmoiron/sqlx"
+)
+
+// SearchSupported reports whether Search is available.
+const SearchSupported = true
+
+// createSearchTables creates the full-text index of the tanks and the
+// triggers keeping it in sync with the tanks table.
+func createSearchTables(db *sqlx.DB) error {
+	//goland:noinspection ALL
+	statements := []string{`
+		CREATE VIRTUAL TABLE IF NOT EXISTS tanks_fts USING fts5(
+			system, line, notes, responsible,
+			content='tanks', content_rowid='id'
+		);`, `
+		CREATE TRIGGER IF NOT EXISTS tanks_fts_insert AFTER INSERT ON tanks BEGIN
+			INSERT INTO tanks_fts(rowid, system, line, notes, responsible)
+				VALUES (new.id, new.system, new.line, new.notes, new.responsible);
+		END;`, `
+		CREATE TRIGGER IF NOT EXISTS tanks_fts_delete AFTER DELETE ON tanks BEGIN
+			INSERT INTO tanks_fts(tanks_fts, rowid, system, line, notes, responsible)
+				VALUES ('delete', old.id, old.system, old.line, old.notes, old.responsible);
+		END;`, `
+		CREATE TRIGGER IF NOT EXISTS tanks_fts_update AFTER UPDATE ON tanks BEGIN
+			INSERT INTO tanks_fts(tanks_fts, rowid, system, line, notes, responsible)
+				VALUES ('delete', old.id, old.system, old.line, old.notes, old.responsible);
+			INSERT INTO tanks_fts(rowid, system, line, notes, responsible)
+				VALUES (new.id, new.system, new.line, new.notes, new.responsible);
+		END;`,
+	}
+	for _, statement := range statements {
+		if _, err := db.Exec(statement); err != nil {
+			return fmt.Errorf("failed to create search index: %w", err)
+		}
+	}
+	return nil
+}
+
+func (tankDB TankDBMock) Search(ctx context.Context, query string, options Options) ([]*SearchResult, error) {
+	match := matchQuery(query)
+	if match == "" {
+		return nil, nil
+	}
+	options.OrderBy = nil
+	options.After = nil
+	filterClause, values, err := options.createFilterClause()
+	if err != nil {
+		return nil, &Error{Op: "search", Kind: ErrInvalidOptions, Err: err}
+	}
+	columnList, err := quoteColumns(Columns)
+	if err != nil {
+		return nil, &Error{Op: "search", Kind: ErrInvalidOptions, Err: err}
+	}
+	if values == nil {
+		values = make(map[string]interface{})
+	}
+	values["match"] = match
+	//goland:noinspection ALL
+	searchStatement := fmt.Sprintf(`
+		SELECT %s, fts.rank, fts.snippet
+		FROM tanks
+		JOIN (
+			SELECT rowid, rank, snippet(tanks_fts, -1, '[', ']', '...', 10) AS snippet
+			FROM tanks_fts
+			WHERE tanks_fts MATCH :match
+		) AS fts ON tanks.id = fts.rowid
+		%s
+		ORDER BY fts.rank, tanks.number
+		%s;
+	`, columnList, filterClause, options.createPaginationClause())
+	rows, err := tankDB.DB.NamedQueryContext(ctx, searchStatement, values)
+	if err != nil {
+		logging.FromContext(ctx, tankDB.logger).Error("failed to search tanks", "query", query, "error", err)
+		return nil, newError("search", 0, err)
+	}
+	defer func(rows *sqlx.Rows) {
+		err := rows.Close()
+		if err != nil {
+			logging.FromContext(ctx, tankDB.logger).Warn("failed closing rows", "error", err)
+		}
+	}(rows)
+
+	var results []*SearchResult
+	for rows.Next() {
+		result := SearchResult{Tank: &model.Tank{}}
+		err = rows.Scan(append(tankFields(result.Tank, Columns), &result.Rank, &result.Snippet)...)
+		if err != nil {
+			return nil, newError("search", 0, err)
+		}
+		results = append(results, &result)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, newError("search", 0, err)
+	}
+	return results, nil
+}
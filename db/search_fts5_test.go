@@ -0,0 +1,77 @@
+//go:build sqlite_fts5
+
+package db_test
+
+import (
+	"context"
+	"testing"
+
+	apiModel "github.com/anchamber/genetics-api/model"
+	"github.com/anchamber/genetics-tank/db"
+	"github.com/anchamber/genetics-tank/db/model"
+)
+
+func TestSearch(t *testing.T) {
+	ctx := context.Background()
+	tankDB := newMockDB(t, []*model.Tank{
+		{System: "3B", Number: 1, Line: "nacre", Notes: "spawned last week", Responsible: "Ada", Lab: "zebrafish"},
+		{System: "3A", Number: 2, Line: "casper", Notes: "nacre cross pending", Responsible: "Ben", Lab: "zebrafish"},
+		{System: "4C", Number: 3, Line: "nacre", Notes: "", Responsible: "Ada", Lab: "medaka"},
+	})
+
+	testCases := []struct {
+		name    string
+		query   string
+		options db.Options
+		numbers []uint32
+	}{
+		{name: "all words have to match", query: "nacre 3B", numbers: []uint32{1}},
+		{name: "prefix", query: "nac", numbers: []uint32{3, 1, 2}},
+		{name: "filters and scope are applied", query: "nacre", options: db.Options{
+			Filters: []*apiModel.Filter{{Key: "responsible", Operator: apiModel.EQ, Value: "Ada"}},
+			Scope:   &db.Scope{Labs: []string{"zebrafish"}},
+		}, numbers: []uint32{1}},
+		{name: "query syntax is escaped", query: `nacre" OR "casper`, numbers: nil},
+	}
+	for _, tc := range testCases {
+		tc := tc
+		t.Run(tc.name, func(t *testing.T) {
+			results, err := tankDB.Search(ctx, tc.query, tc.options)
+			if err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+			var numbers []uint32
+			for _, result := range results {
+				numbers = append(numbers, result.Tank.Number)
+			}
+			if len(numbers) != len(tc.numbers) {
+				t.Fatalf("wrong results, expected: %v | actual: %v", tc.numbers, numbers)
+			}
+			for i := range numbers {
+				if numbers[i] != tc.numbers[i] {
+					t.Fatalf("wrong results, expected: %v | actual: %v", tc.numbers, numbers)
+				}
+			}
+		})
+	}
+
+	t.Run("index follows updates and deletes", func(t *testing.T) {
+		if err := tankDB.Update(ctx, &model.Tank{System: "3A", Number: 2, Line: "casper", Notes: "moved"}); err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+		if err := tankDB.Delete(ctx, 3); err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+		results, err := tankDB.Search(ctx, "nacre", db.Options{})
+		if err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+		if len(results) != 1 || results[0].Tank.Number != 1 {
+			t.Fatalf("expected only tank 1, got %d results", len(results))
+		}
+		if results[0].Snippet == "" {
+			t.Error("snippet missing")
+		}
+		t.Log(results[0].Snippet)
+	})
+}
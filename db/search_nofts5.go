@@ -0,0 +1,23 @@
+//go:build !sqlite_fts5
+
+package db
+
+import (
+	"context"
+
+	"github.com/jmoiron/sqlx"
+)
+
+// SearchSupported reports whether Search is available. Without it Search
+// returns an error of kind ErrUnsupported, see the Makefile.
+const SearchSupported = false
+
+// createSearchTables does nothing, full-text search needs SQLite with FTS5,
+// which is enabled with the sqlite_fts5 build tag.
+func createSearchTables(db *sqlx.DB) error {
+	return nil
+}
+
+func (tankDB TankDBMock) Search(ctx context.Context, query string, options Options) ([]*SearchResult, error) {
+	return nil, &Error{Op: "search", Kind: ErrUnsupported}
+}
@@ -1,13 +1,27 @@
 module github.com/anchamber/genetics-tank
 
-go 1.16
+go 1.21
 
 require (
+	github.com/BurntSushi/toml v1.4.0
 	github.com/anchamber/genetics-api v0.0.0-20210430170927-4e67ae97838d
+	github.com/boombuler/barcode v1.0.1
+	github.com/golang-jwt/jwt/v4 v4.4.2
+	github.com/grpc-ecosystem/grpc-gateway/v2 v2.16.0
 	github.com/jmoiron/sqlx v1.3.3
 	github.com/mattn/go-sqlite3 v1.14.7
 	github.com/mennanov/fmutils v0.1.0
-	google.golang.org/genproto v0.0.0-20210506142907-4a47615972c2 // indirect
-	google.golang.org/grpc v1.37.0
-	google.golang.org/protobuf v1.26.0
-)
\ No newline at end of file
+	github.com/prometheus/client_golang v1.14.0
+	go.opentelemetry.io/otel v1.19.0
+	go.opentelemetry.io/otel/exporters/otlp/otlptrace v1.19.0
+	go.opentelemetry.io/otel/exporters/stdout/stdouttrace v1.19.0
+	go.opentelemetry.io/otel/sdk v1.19.0
+	go.opentelemetry.io/otel/trace v1.19.0
+	go.opentelemetry.io/proto/otlp v1.0.0
+	golang.org/x/image v0.18.0
+	google.golang.org/genproto v0.0.0-20230526203410-71b5a4ffd15e
+	google.golang.org/genproto/googleapis/rpc v0.0.0-20230530153820-e85fd2cbaebc
+	google.golang.org/grpc v1.56.2
+	google.golang.org/protobuf v1.31.0
+	gopkg.in/yaml.v3 v3.0.1
+)
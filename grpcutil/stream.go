@@ -0,0 +1,23 @@
+// Package grpcutil contains helpers shared by the gRPC interceptors.
+package grpcutil
+
+import (
+	"context"
+
+	"google.golang.org/grpc"
+)
+
+// WithContext returns ss with its context replaced by ctx, so a stream
+// interceptor can pass values to the handler.
+func WithContext(ss grpc.ServerStream, ctx context.Context) grpc.ServerStream {
+	return &wrappedStream{ServerStream: ss, ctx: ctx}
+}
+
+type wrappedStream struct {
+	grpc.ServerStream
+	ctx context.Context
+}
+
+func (w *wrappedStream) Context() context.Context {
+	return w.ctx
+}
@@ -0,0 +1,79 @@
+package healthcheck
+
+import (
+	"context"
+	"log/slog"
+	"sync"
+	"time"
+
+	"github.com/anchamber/genetics-tank/db"
+	"google.golang.org/grpc/health"
+	healthpb "google.golang.org/grpc/health/grpc_health_v1"
+)
+
+// Checker reports the services as serving as long as the database can be pinged.
+type Checker struct {
+	server   *health.Server
+	db       db.TankDB
+	services []string
+
+	stopOnce sync.Once
+	done     chan struct{}
+}
+
+// New creates a checker updating server for the given services. The overall
+// health of the server ("") is always updated.
+func New(server *health.Server, tankDB db.TankDB, services ...string) *Checker {
+	return &Checker{
+		server:   server,
+		db:       tankDB,
+		services: append([]string{""}, services...),
+		done:     make(chan struct{}),
+	}
+}
+
+// Start checks the database immediately and then every interval until
+// Shutdown is called.
+func (c *Checker) Start(interval time.Duration) {
+	c.check(interval)
+	go func() {
+		ticker := time.NewTicker(interval)
+		defer ticker.Stop()
+		for {
+			select {
+			case <-c.done:
+				return
+			case <-ticker.C:
+				c.check(interval)
+			}
+		}
+	}()
+}
+
+// Check pings the database and updates the serving status accordingly.
+func (c *Checker) Check(ctx context.Context) {
+	status := healthpb.HealthCheckResponse_SERVING
+	if err := c.db.Ping(ctx); err != nil {
+		slog.Warn("database ping failed", "error", err)
+		status = healthpb.HealthCheckResponse_NOT_SERVING
+	}
+	for _, service := range c.services {
+		c.server.SetServingStatus(service, status)
+	}
+}
+
+// check pings the database, giving up after interval.
+func (c *Checker) check(interval time.Duration) {
+	ctx, cancel := context.WithTimeout(context.Background(), interval)
+	defer cancel()
+	c.Check(ctx)
+}
+
+// Shutdown stops checking the database and sets all services to NOT_SERVING.
+// Later status updates are ignored.
+func (c *Checker) Shutdown() {
+	c.stopOnce.Do(func() {
+		close(c.done)
+	})
+	c.server.Shutdown()
+}
@@ -0,0 +1,60 @@
+package healthcheck_test
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/anchamber/genetics-tank/db"
+	"github.com/anchamber/genetics-tank/healthcheck"
+	"google.golang.org/grpc/health"
+	healthpb "google.golang.org/grpc/health/grpc_health_v1"
+)
+
+const service = "anchamber.genetics.TankService"
+
+func newMockDB(t *testing.T) db.TankDBMock {
+	t.Helper()
+	tankDB, err := db.NewMockDB(nil, nil)
+	if err != nil {
+		t.Fatalf("failed to create database: %v", err)
+	}
+	return tankDB
+}
+
+func TestChecker(t *testing.T) {
+	tankDB := newMockDB(t)
+	server := health.NewServer()
+	checker := healthcheck.New(server, tankDB, service)
+
+	checker.Check(context.Background())
+	assertStatus(t, server, service, healthpb.HealthCheckResponse_SERVING)
+
+	if err := tankDB.DB.Close(); err != nil {
+		t.Fatalf("failed to close db: %v", err)
+	}
+	checker.Check(context.Background())
+	assertStatus(t, server, "", healthpb.HealthCheckResponse_NOT_SERVING)
+	assertStatus(t, server, service, healthpb.HealthCheckResponse_NOT_SERVING)
+}
+
+func TestShutdown(t *testing.T) {
+	server := health.NewServer()
+	checker := healthcheck.New(server, newMockDB(t), service)
+	checker.Start(time.Hour)
+	checker.Shutdown()
+	checker.Check(context.Background())
+
+	assertStatus(t, server, service, healthpb.HealthCheckResponse_NOT_SERVING)
+}
+
+func assertStatus(t *testing.T, server *health.Server, service string, expected healthpb.HealthCheckResponse_ServingStatus) {
+	t.Helper()
+	response, err := server.Check(context.Background(), &healthpb.HealthCheckRequest{Service: service})
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if response.Status != expected {
+		t.Errorf("expected status %v for %q, got %v", expected, service, response.Status)
+	}
+}
@@ -0,0 +1,245 @@
+// Package label renders the labels of tanks with the tank number as barcode
+// or QR code, as SVG or PNG sheets for office printers or as ZPL for thermal
+// label printers.
+package label
+
+import (
+	"fmt"
+	"math"
+	"strconv"
+
+	"github.com/boombuler/barcode"
+	"github.com/boombuler/barcode/code128"
+	"github.com/boombuler/barcode/qr"
+)
+
+// Format is the format of the rendered pages.
+type Format int
+
+const (
+	SVG Format = iota
+	PNG
+	ZPL
+)
+
+// ContentType is the MIME type of the pages in the format.
+func (f Format) ContentType() string {
+	switch f {
+	case PNG:
+		return "image/png"
+	case ZPL:
+		return "application/zpl"
+	default:
+		return "image/svg+xml"
+	}
+}
+
+// Symbology is the kind of code the tank number is encoded in.
+type Symbology int
+
+const (
+	Code128 Symbology = iota
+	QR
+)
+
+// quietZone is the number of empty modules required around a code.
+func (s Symbology) quietZone() int {
+	if s == QR {
+		return 4
+	}
+	return 10
+}
+
+// Label is the content of the label of a tank.
+type Label struct {
+	Number uint32
+	System string
+	Line   string
+}
+
+// Options select how labels are rendered.
+type Options struct {
+	Format    Format
+	Symbology Symbology
+	Template  Template
+}
+
+// canvas draws black shapes on a white page. Coordinates are in millimeters
+// from the top left corner of the page.
+type canvas interface {
+	rect(x, y, width, height float64)
+	// text draws s with its top left corner at x, y.
+	text(x, y, size float64, s string)
+	encode() ([]byte, error)
+}
+
+// Render returns the pages with the labels. SVG and PNG pages are sheets
+// with as many labels as the template has room for, ZPL output is a single
+// page with every label as its own format.
+func Render(labels []Label, options Options) ([][]byte, error) {
+	if err := options.Template.Validate(); err != nil {
+		return nil, fmt.Errorf("invalid template: %w", err)
+	}
+	if options.Format == ZPL {
+		page, err := renderZPL(labels, options)
+		if err != nil {
+			return nil, err
+		}
+		return [][]byte{page}, nil
+	}
+
+	var pages [][]byte
+	perPage := options.Template.perPage()
+	for start := 0; start < len(labels); start += perPage {
+		var c canvas
+		switch options.Format {
+		case SVG:
+			c = newSVGCanvas(options.Template)
+		case PNG:
+			c = newPNGCanvas(options.Template)
+		default:
+			return nil, fmt.Errorf("unknown format %d", options.Format)
+		}
+		for i, label := range labels[start:min(start+perPage, len(labels))] {
+			x, y := options.Template.origin(i)
+			if err := drawLabel(c, x, y, label, options); err != nil {
+				return nil, err
+			}
+		}
+		page, err := c.encode()
+		if err != nil {
+			return nil, err
+		}
+		pages = append(pages, page)
+	}
+	return pages, nil
+}
+
+func drawLabel(c canvas, x float64, y float64, label Label, options Options) error {
+	textBox, codeBox := layout(options.Template, options.Symbology)
+	for _, line := range textLines(label, textBox) {
+		c.text(x+line.x, y+line.y, line.size, line.text)
+	}
+	code, err := encode(label.Number, options.Symbology)
+	if err != nil {
+		return err
+	}
+	codeBox.x += x
+	codeBox.y += y
+	drawCode(c, codeBox, code, options.Symbology)
+	return nil
+}
+
+// box is an area of a label in millimeters.
+type box struct {
+	x, y, width, height float64
+}
+
+// layout splits a label into the area of the text and the area of the code.
+// A QR code is placed to the right of the text, a barcode below it.
+func layout(template Template, symbology Symbology) (box, box) {
+	padding := math.Min(template.LabelWidth, template.LabelHeight) * 0.08
+	width := template.LabelWidth - 2*padding
+	height := template.LabelHeight - 2*padding
+	if symbology == QR {
+		side := math.Min(height, width/2)
+		return box{x: padding, y: padding, width: width - side - padding, height: height},
+			box{x: padding + width - side, y: padding, width: side, height: side}
+	}
+	codeHeight := height * 0.45
+	return box{x: padding, y: padding, width: width, height: height - codeHeight - padding},
+		box{x: padding, y: padding + height - codeHeight, width: width, height: codeHeight}
+}
+
+type textLine struct {
+	x, y, size float64
+	text       string
+}
+
+// textLines places the number, system and line of a tank in area. The
+// number is set larger than the rest, text too wide for area is scaled down.
+func textLines(label Label, area box) []textLine {
+	texts := []string{"#" + strconv.FormatUint(uint64(label.Number), 10), "System " + label.System}
+	if label.Line != "" {
+		texts = append(texts, "Line "+label.Line)
+	}
+	const titleScale = 1.6
+	// charWidth is the width of an average character relative to its size.
+	const charWidth = 0.6
+	unit := area.height / (titleScale + float64(len(texts)-1)) / 1.2
+
+	var lines []textLine
+	y := area.y
+	for i, text := range texts {
+		size := unit
+		if i == 0 {
+			size *= titleScale
+		}
+		if width := float64(len(text)) * charWidth * size; width > area.width {
+			size *= area.width / width
+		}
+		lines = append(lines, textLine{x: area.x, y: y, size: size, text: text})
+		if i == 0 {
+			y += unit * titleScale * 1.2
+		} else {
+			y += unit * 1.2
+		}
+	}
+	return lines
+}
+
+func encode(number uint32, symbology Symbology) (barcode.Barcode, error) {
+	content := Payload(number)
+	var code barcode.Barcode
+	var err error
+	switch symbology {
+	case Code128:
+		code, err = code128.Encode(content)
+	case QR:
+		code, err = qr.Encode(content, qr.M, qr.Auto)
+	default:
+		return nil, fmt.Errorf("unknown symbology %d", symbology)
+	}
+	if err != nil {
+		return nil, fmt.Errorf("failed to encode tank %d: %w", number, err)
+	}
+	return code, nil
+}
+
+// drawCode draws the modules of code into area, leaving room for the quiet
+// zone. Modules of QR codes are square, bars of barcodes fill the height of
+// area.
+func drawCode(c canvas, area box, code barcode.Barcode, symbology Symbology) {
+	bounds := code.Bounds()
+	quietZone := float64(symbology.quietZone())
+	moduleWidth := area.width / (float64(bounds.Dx()) + 2*quietZone)
+	moduleHeight := area.height / float64(bounds.Dy())
+	offsetY := 0.0
+	if symbology == QR {
+		moduleWidth = math.Min(moduleWidth, area.height/(float64(bounds.Dy())+2*quietZone))
+		moduleHeight = moduleWidth
+		offsetY = quietZone * moduleWidth
+	}
+	offsetX := quietZone * moduleWidth
+	for y := bounds.Min.Y; y < bounds.Max.Y; y++ {
+		for x := bounds.Min.X; x < bounds.Max.X; {
+			if !isBlack(code, x, y) {
+				x++
+				continue
+			}
+			start := x
+			for x < bounds.Max.X && isBlack(code, x, y) {
+				x++
+			}
+			c.rect(area.x+offsetX+float64(start-bounds.Min.X)*moduleWidth,
+				area.y+offsetY+float64(y-bounds.Min.Y)*moduleHeight,
+				float64(x-start)*moduleWidth,
+				moduleHeight)
+		}
+	}
+}
+
+func isBlack(code barcode.Barcode, x int, y int) bool {
+	r, _, _, _ := code.At(x, y).RGBA()
+	return r < 0x8000
+}
@@ -0,0 +1,181 @@
+package label_test
+
+import (
+	"bytes"
+	"encoding/xml"
+	"errors"
+	"image/png"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/anchamber/genetics-tank/label"
+)
+
+func labels(n int) []label.Label {
+	labels := make([]label.Label, n)
+	for i := range labels {
+		labels[i] = label.Label{Number: uint32(i + 1), System: "A", Line: "casper"}
+	}
+	return labels
+}
+
+func TestRender(t *testing.T) {
+	sheet := label.Templates[label.DefaultSheetTemplate]
+	thermal := label.Templates[label.DefaultThermalTemplate]
+	testCases := []struct {
+		name    string
+		labels  []label.Label
+		options label.Options
+		pages   int
+		check   func(t *testing.T, page []byte)
+	}{
+		{
+			name:    "svg sheets",
+			labels:  labels(25),
+			options: label.Options{Format: label.SVG, Template: sheet},
+			pages:   2,
+			check: func(t *testing.T, page []byte) {
+				if err := xml.Unmarshal(page, new(struct{})); err != nil {
+					t.Errorf("invalid svg: %v", err)
+				}
+			},
+		},
+		{
+			name:    "png sheet with qr codes",
+			labels:  labels(3),
+			options: label.Options{Format: label.PNG, Symbology: label.QR, Template: sheet},
+			pages:   1,
+			check: func(t *testing.T, page []byte) {
+				config, err := png.DecodeConfig(bytes.NewReader(page))
+				if err != nil {
+					t.Fatalf("invalid png: %v", err)
+				}
+				if width := int(sheet.PageWidth / 25.4 * float64(sheet.DPI)); config.Width < width-1 || config.Width > width+1 {
+					t.Errorf("wrong width, expected: %d | actual: %d", width, config.Width)
+				}
+			},
+		},
+		{
+			name:    "zpl",
+			labels:  labels(3),
+			options: label.Options{Format: label.ZPL, Template: thermal},
+			pages:   1,
+			check: func(t *testing.T, page []byte) {
+				if count := strings.Count(string(page), "^XA"); count != 3 {
+					t.Errorf("wrong number of formats, expected: 3 | actual: %d", count)
+				}
+				if !strings.Contains(string(page), "^BCN") {
+					t.Error("missing barcode")
+				}
+			},
+		},
+		{
+			name:    "zpl escapes field data",
+			labels:  []label.Label{{Number: 1, System: "A^B", Line: "x_y"}},
+			options: label.Options{Format: label.ZPL, Symbology: label.QR, Template: thermal},
+			pages:   1,
+			check: func(t *testing.T, page []byte) {
+				if !strings.Contains(string(page), "A_5EB") || !strings.Contains(string(page), "x_5Fy") {
+					t.Errorf("field data is not escaped: %s", page)
+				}
+			},
+		},
+	}
+	for _, tc := range testCases {
+		tc := tc
+		t.Run(tc.name, func(t *testing.T) {
+			t.Parallel()
+			pages, err := label.Render(tc.labels, tc.options)
+			if err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+			if len(pages) != tc.pages {
+				t.Fatalf("wrong number of pages, expected: %d | actual: %d", tc.pages, len(pages))
+			}
+			for _, page := range pages {
+				tc.check(t, page)
+			}
+		})
+	}
+}
+
+func TestTemplates(t *testing.T) {
+	for name, template := range label.Templates {
+		if err := template.Validate(); err != nil {
+			t.Errorf("built-in template %s is invalid: %v", name, err)
+		}
+	}
+
+	tooWide := label.Templates[label.DefaultSheetTemplate]
+	tooWide.Columns = 4
+	if err := tooWide.Validate(); err == nil {
+		t.Error("expected error for labels wider than the page")
+	}
+	if _, err := label.Render(labels(1), label.Options{Template: tooWide}); err == nil {
+		t.Error("expected error rendering with an invalid template")
+	}
+
+	path := filepath.Join(t.TempDir(), "templates.yaml")
+	content := "custom:\n  page_width: 60\n  page_height: 30\n  label_width: 60\n  label_height: 30\n  columns: 1\n  rows: 1\n  dpi: 300\n"
+	if err := os.WriteFile(path, []byte(content), 0o600); err != nil {
+		t.Fatal(err)
+	}
+	templates, err := label.LoadTemplates(path)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if templates["custom"].DPI != 300 {
+		t.Errorf("custom template not loaded: %+v", templates["custom"])
+	}
+	if _, ok := templates[label.DefaultSheetTemplate]; !ok {
+		t.Error("built-in templates missing")
+	}
+
+	if err := os.WriteFile(path, []byte("custom:\n  colums: 1\n"), 0o600); err != nil {
+		t.Fatal(err)
+	}
+	if _, err := label.LoadTemplates(path); err == nil {
+		t.Error("expected error for unknown field")
+	}
+}
+
+func TestParsePayload(t *testing.T) {
+	testCases := []struct {
+		name          string
+		payload       string
+		number        uint32
+		expectedError bool
+	}{
+		{name: "current version", payload: label.Payload(42), number: 42},
+		{name: "version 0", payload: "42", number: 42},
+		{name: "lower case prefix", payload: "t1:7", number: 7},
+		{name: "scanner suffix", payload: "T1:42\r\n", number: 42},
+		{name: "symbology identifier", payload: "]Q1T1:42", number: 42},
+		{name: "future version", payload: "T9:42", expectedError: true},
+		{name: "unknown prefix", payload: "X1:42", expectedError: true},
+		{name: "invalid number", payload: "T1:42a", expectedError: true},
+		{name: "number out of range", payload: "T1:4294967296", expectedError: true},
+		{name: "empty", payload: "", expectedError: true},
+	}
+	for _, tc := range testCases {
+		tc := tc
+		t.Run(tc.name, func(t *testing.T) {
+			t.Parallel()
+			number, err := label.ParsePayload(tc.payload)
+			if tc.expectedError {
+				if !errors.Is(err, label.ErrInvalidPayload) {
+					t.Errorf("expected ErrInvalidPayload, got: %v", err)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+			if number != tc.number {
+				t.Errorf("wrong number, expected: %d | actual: %d", tc.number, number)
+			}
+		})
+	}
+}
@@ -0,0 +1,56 @@
+package label
+
+import (
+	"errors"
+	"fmt"
+	"strconv"
+	"strings"
+	"unicode"
+)
+
+// PayloadVersion is the version of the payload encoded on new labels.
+//
+// Version 0 labels contain only the tank number. Version 1 labels contain
+// "T1:" followed by the tank number, so that they can be told apart from
+// other codes in the facility.
+const PayloadVersion = 1
+
+// ErrInvalidPayload is returned for scans that are not a tank label.
+var ErrInvalidPayload = errors.New("not a tank label")
+
+// Payload returns the content of the code on the label of a tank.
+func Payload(number uint32) string {
+	return fmt.Sprintf("T%d:%d", PayloadVersion, number)
+}
+
+// ParsePayload returns the tank number of a scanned label. It accepts the
+// payloads of all label versions, surrounding whitespace and control
+// characters added by scanners and a leading AIM symbology identifier like
+// "]C0" or "]Q1".
+func ParsePayload(payload string) (uint32, error) {
+	payload = strings.TrimFunc(payload, func(r rune) bool {
+		return unicode.IsSpace(r) || unicode.IsControl(r)
+	})
+	if strings.HasPrefix(payload, "]") && len(payload) >= 3 {
+		payload = payload[3:]
+	}
+	version, content := 0, payload
+	if prefix, rest, ok := strings.Cut(payload, ":"); ok {
+		if len(prefix) < 2 || (prefix[0] != 'T' && prefix[0] != 't') {
+			return 0, fmt.Errorf("%w: unknown prefix %q", ErrInvalidPayload, prefix)
+		}
+		v, err := strconv.ParseUint(prefix[1:], 10, 8)
+		if err != nil || v < 1 {
+			return 0, fmt.Errorf("%w: unknown prefix %q", ErrInvalidPayload, prefix)
+		}
+		version, content = int(v), rest
+	}
+	if version > PayloadVersion {
+		return 0, fmt.Errorf("%w: unsupported version %d", ErrInvalidPayload, version)
+	}
+	number, err := strconv.ParseUint(content, 10, 32)
+	if err != nil {
+		return 0, fmt.Errorf("%w: invalid tank number %q", ErrInvalidPayload, content)
+	}
+	return uint32(number), nil
+}
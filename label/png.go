@@ -0,0 +1,71 @@
+package label
+
+import (
+	"bytes"
+	"image"
+	"image/color"
+	"image/draw"
+	"image/png"
+	"math"
+
+	"golang.org/x/image/font"
+	"golang.org/x/image/font/basicfont"
+	"golang.org/x/image/math/fixed"
+)
+
+// pngCanvas draws a page in the resolution of the template.
+type pngCanvas struct {
+	image *image.Gray
+	// scale is the number of pixels per millimeter.
+	scale float64
+}
+
+func newPNGCanvas(template Template) *pngCanvas {
+	scale := float64(template.DPI) / 25.4
+	page := image.NewGray(image.Rect(0, 0, int(math.Ceil(template.PageWidth*scale)), int(math.Ceil(template.PageHeight*scale))))
+	draw.Draw(page, page.Bounds(), image.White, image.Point{}, draw.Src)
+	return &pngCanvas{image: page, scale: scale}
+}
+
+func (c *pngCanvas) rect(x, y, width, height float64) {
+	area := image.Rect(
+		int(math.Round(x*c.scale)), int(math.Round(y*c.scale)),
+		int(math.Round((x+width)*c.scale)), int(math.Round((y+height)*c.scale)))
+	draw.Draw(c.image, area, image.Black, image.Point{}, draw.Src)
+}
+
+// text renders s with the fixed 7x13 pixel font, scaled by whole pixels to
+// get as close to size as possible.
+func (c *pngCanvas) text(x, y, size float64, s string) {
+	face := basicfont.Face7x13
+	glyphs := image.NewAlpha(image.Rect(0, 0, font.MeasureString(face, s).Ceil(), face.Height))
+	drawer := font.Drawer{
+		Dst:  glyphs,
+		Src:  image.Opaque,
+		Face: face,
+		Dot:  fixed.P(0, face.Ascent),
+	}
+	drawer.DrawString(s)
+
+	factor := int(math.Max(1, math.Round(size*c.scale/float64(face.Height))))
+	left := int(math.Round(x * c.scale))
+	top := int(math.Round(y * c.scale))
+	bounds := glyphs.Bounds()
+	for gy := bounds.Min.Y; gy < bounds.Max.Y; gy++ {
+		for gx := bounds.Min.X; gx < bounds.Max.X; gx++ {
+			if glyphs.AlphaAt(gx, gy).A < 0x80 {
+				continue
+			}
+			pixel := image.Rect(left+gx*factor, top+gy*factor, left+(gx+1)*factor, top+(gy+1)*factor)
+			draw.Draw(c.image, pixel, &image.Uniform{C: color.Black}, image.Point{}, draw.Src)
+		}
+	}
+}
+
+func (c *pngCanvas) encode() ([]byte, error) {
+	var buffer bytes.Buffer
+	if err := png.Encode(&buffer, c.image); err != nil {
+		return nil, err
+	}
+	return buffer.Bytes(), nil
+}
@@ -0,0 +1,35 @@
+package label
+
+import (
+	"bytes"
+	"encoding/xml"
+	"fmt"
+)
+
+// svgCanvas draws a page in millimeters.
+type svgCanvas struct {
+	buffer bytes.Buffer
+}
+
+func newSVGCanvas(template Template) *svgCanvas {
+	c := &svgCanvas{}
+	fmt.Fprintf(&c.buffer, `<svg xmlns="http://www.w3.org/2000/svg" width="%gmm" height="%gmm" viewBox="0 0 %g %g">`,
+		template.PageWidth, template.PageHeight, template.PageWidth, template.PageHeight)
+	fmt.Fprintf(&c.buffer, `<rect width="%g" height="%g" fill="#fff"/>`, template.PageWidth, template.PageHeight)
+	return c
+}
+
+func (c *svgCanvas) rect(x, y, width, height float64) {
+	fmt.Fprintf(&c.buffer, `<rect x="%.3f" y="%.3f" width="%.3f" height="%.3f"/>`, x, y, width, height)
+}
+
+func (c *svgCanvas) text(x, y, size float64, s string) {
+	fmt.Fprintf(&c.buffer, `<text x="%.3f" y="%.3f" font-family="sans-serif" font-size="%.3f">`, x, y+size*0.8, size)
+	_ = xml.EscapeText(&c.buffer, []byte(s))
+	c.buffer.WriteString("</text>")
+}
+
+func (c *svgCanvas) encode() ([]byte, error) {
+	c.buffer.WriteString("</svg>")
+	return c.buffer.Bytes(), nil
+}
@@ -0,0 +1,130 @@
+package label
+
+import (
+	"bytes"
+	"errors"
+	"fmt"
+	"io"
+	"os"
+
+	"gopkg.in/yaml.v3"
+)
+
+// Template is the layout of labels on a page. All lengths are in
+// millimeters.
+type Template struct {
+	PageWidth   float64 `yaml:"page_width"`
+	PageHeight  float64 `yaml:"page_height"`
+	LabelWidth  float64 `yaml:"label_width"`
+	LabelHeight float64 `yaml:"label_height"`
+	// Columns and Rows are the number of labels on a page.
+	Columns int `yaml:"columns"`
+	Rows    int `yaml:"rows"`
+	// MarginLeft and MarginTop are the distance of the first label from the
+	// edges of the page, GapX and GapY the distance between labels.
+	MarginLeft float64 `yaml:"margin_left"`
+	MarginTop  float64 `yaml:"margin_top"`
+	GapX       float64 `yaml:"gap_x"`
+	GapY       float64 `yaml:"gap_y"`
+	// DPI is the resolution of PNG and ZPL output.
+	DPI int `yaml:"dpi"`
+}
+
+// DefaultSheetTemplate is used for SVG and PNG, DefaultThermalTemplate for
+// ZPL if no template is requested.
+const (
+	DefaultSheetTemplate   = "a4-3x8"
+	DefaultThermalTemplate = "thermal-50x25"
+)
+
+// Templates are the built-in templates by name.
+var Templates = map[string]Template{
+	"a4-3x8": {
+		PageWidth: 210, PageHeight: 297,
+		LabelWidth: 70, LabelHeight: 37,
+		Columns: 3, Rows: 8,
+		MarginTop: 0.5,
+		DPI:       150,
+	},
+	"letter-3x10": {
+		PageWidth: 215.9, PageHeight: 279.4,
+		LabelWidth: 66.7, LabelHeight: 25.4,
+		Columns: 3, Rows: 10,
+		MarginLeft: 4.8, MarginTop: 12.7,
+		GapX: 3.2,
+		DPI:  150,
+	},
+	"thermal-50x25": {
+		PageWidth: 50, PageHeight: 25,
+		LabelWidth: 50, LabelHeight: 25,
+		Columns: 1, Rows: 1,
+		DPI: 203,
+	},
+	"thermal-100x50": {
+		PageWidth: 100, PageHeight: 50,
+		LabelWidth: 100, LabelHeight: 50,
+		Columns: 1, Rows: 1,
+		DPI: 203,
+	},
+}
+
+// Validate checks that the labels fit on the page.
+func (t Template) Validate() error {
+	var errs []error
+	if t.LabelWidth <= 0 || t.LabelHeight <= 0 {
+		errs = append(errs, fmt.Errorf("label size must be positive"))
+	}
+	if t.Columns < 1 || t.Rows < 1 {
+		errs = append(errs, fmt.Errorf("columns and rows must be at least 1"))
+	}
+	if t.DPI < 72 || t.DPI > 1200 {
+		errs = append(errs, fmt.Errorf("dpi must be between 72 and 1200"))
+	}
+	if t.MarginLeft < 0 || t.MarginTop < 0 || t.GapX < 0 || t.GapY < 0 {
+		errs = append(errs, fmt.Errorf("margins and gaps must not be negative"))
+	}
+	width := t.MarginLeft + float64(t.Columns)*t.LabelWidth + float64(t.Columns-1)*t.GapX
+	height := t.MarginTop + float64(t.Rows)*t.LabelHeight + float64(t.Rows-1)*t.GapY
+	if width > t.PageWidth+0.01 || height > t.PageHeight+0.01 {
+		errs = append(errs, fmt.Errorf("labels need %.1fx%.1fmm but the page is %.1fx%.1fmm", width, height, t.PageWidth, t.PageHeight))
+	}
+	return errors.Join(errs...)
+}
+
+// perPage is the number of labels on a page.
+func (t Template) perPage() int {
+	return t.Columns * t.Rows
+}
+
+// origin returns the top left corner of the i-th label of a page.
+func (t Template) origin(i int) (float64, float64) {
+	column := i % t.Columns
+	row := i / t.Columns
+	return t.MarginLeft + float64(column)*(t.LabelWidth+t.GapX), t.MarginTop + float64(row)*(t.LabelHeight+t.GapY)
+}
+
+// LoadTemplates reads templates by name from a YAML file and adds them to
+// the built-in ones, replacing built-in templates of the same name.
+func LoadTemplates(path string) (map[string]Template, error) {
+	content, err := os.ReadFile(path)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read label templates: %w", err)
+	}
+	var loaded map[string]Template
+	decoder := yaml.NewDecoder(bytes.NewReader(content))
+	decoder.KnownFields(true)
+	if err := decoder.Decode(&loaded); err != nil && !errors.Is(err, io.EOF) {
+		return nil, fmt.Errorf("failed to parse %s: %w", path, err)
+	}
+	templates := make(map[string]Template, len(Templates)+len(loaded))
+	for name, template := range Templates {
+		templates[name] = template
+	}
+	for name, template := range loaded {
+		if err := template.Validate(); err != nil {
+			return nil, fmt.Errorf("label template %s: %w", name, err)
+		}
+		templates[name] = template
+	}
+	return templates, nil
+}
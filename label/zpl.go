@@ -0,0 +1,58 @@
+package label
+
+import (
+	"bytes"
+	"fmt"
+	"math"
+	"strings"
+)
+
+// renderZPL renders every label as a ZPL format. Text is set in the scalable
+// printer font 0, codes are rendered by the printer.
+func renderZPL(labels []Label, options Options) ([]byte, error) {
+	template := options.Template
+	// dots converts millimeters to printer dots.
+	dots := func(mm float64) int {
+		return int(math.Round(mm * float64(template.DPI) / 25.4))
+	}
+	textBox, codeBox := layout(template, options.Symbology)
+	quietZone := options.Symbology.quietZone()
+
+	var buffer bytes.Buffer
+	for _, label := range labels {
+		code, err := encode(label.Number, options.Symbology)
+		if err != nil {
+			return nil, err
+		}
+		modules := code.Bounds().Dx() + 2*quietZone
+		fmt.Fprintf(&buffer, "^XA^CI28^PW%d^LL%d\n", dots(template.LabelWidth), dots(template.LabelHeight))
+		for _, line := range textLines(label, textBox) {
+			fmt.Fprintf(&buffer, "^FO%d,%d^A0N,%d,%d^FH^FD%s^FS\n", dots(line.x), dots(line.y), dots(line.size), dots(line.size), escapeZPL(line.text))
+		}
+		payload := Payload(label.Number)
+		switch options.Symbology {
+		case QR:
+			// The magnification is the size of a module in dots.
+			side := math.Min(codeBox.width, codeBox.height)
+			magnification := clamp(dots(side)/modules, 1, 10)
+			offset := dots(float64(quietZone) * side / float64(modules))
+			fmt.Fprintf(&buffer, "^FO%d,%d^BQN,2,%d^FDQA,%s^FS\n", dots(codeBox.x)+offset, dots(codeBox.y)+offset, magnification, payload)
+		default:
+			moduleWidth := clamp(dots(codeBox.width)/modules, 1, 10)
+			offset := quietZone * moduleWidth
+			fmt.Fprintf(&buffer, "^FO%d,%d^BY%d^BCN,%d,N,N,N^FD%s^FS\n", dots(codeBox.x)+offset, dots(codeBox.y), moduleWidth, dots(codeBox.height), payload)
+		}
+		buffer.WriteString("^XZ\n")
+	}
+	return buffer.Bytes(), nil
+}
+
+// escapeZPL hex encodes the characters with a meaning in ZPL for use with
+// ^FH.
+func escapeZPL(s string) string {
+	return strings.NewReplacer("_", "_5F", "^", "_5E", "~", "_7E").Replace(s)
+}
+
+func clamp(value int, lower int, upper int) int {
+	return max(lower, min(value, upper))
+}
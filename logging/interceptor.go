@@ -0,0 +1,111 @@
+package logging
+
+import (
+	"context"
+	"crypto/rand"
+	"encoding/hex"
+	"log/slog"
+	"time"
+
+	"github.com/anchamber/genetics-tank/grpcutil"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/metadata"
+	"google.golang.org/grpc/status"
+)
+
+// RequestIDHeader is the metadata key the request ID is read from and
+// returned in.
+const RequestIDHeader = "x-request-id"
+
+// RequestLogger puts a logger into the context of every RPC that tags each
+// record with the request ID, the RPC method, the tank number of requests for
+// a single tank and the time since the request started. It logs the outcome
+// of every RPC.
+type RequestLogger struct {
+	logger *slog.Logger
+}
+
+func NewRequestLogger(logger *slog.Logger) *RequestLogger {
+	return &RequestLogger{
+		logger: logger,
+	}
+}
+
+func (l *RequestLogger) UnaryInterceptor(ctx context.Context, req interface{}, info *grpc.UnaryServerInfo, handler grpc.UnaryHandler) (interface{}, error) {
+	start := time.Now()
+	requestID := requestIDFromContext(ctx)
+	_ = grpc.SetHeader(ctx, metadata.Pairs(RequestIDHeader, requestID))
+	logger := l.requestLogger(requestID, info.FullMethod, start)
+	if r, ok := req.(numberedRequest); ok {
+		logger = logger.With("number", r.GetNumber())
+	}
+	resp, err := handler(NewContext(ctx, logger), req)
+	logFinished(ctx, logger, err)
+	return resp, err
+}
+
+func (l *RequestLogger) StreamInterceptor(srv interface{}, ss grpc.ServerStream, info *grpc.StreamServerInfo, handler grpc.StreamHandler) error {
+	start := time.Now()
+	requestID := requestIDFromContext(ss.Context())
+	_ = ss.SetHeader(metadata.Pairs(RequestIDHeader, requestID))
+	logger := l.requestLogger(requestID, info.FullMethod, start)
+	err := handler(srv, grpcutil.WithContext(ss, NewContext(ss.Context(), logger)))
+	logFinished(ss.Context(), logger, err)
+	return err
+}
+
+// numberedRequest is implemented by the requests for a single tank.
+type numberedRequest interface {
+	GetNumber() uint32
+}
+
+func (l *RequestLogger) requestLogger(requestID string, method string, start time.Time) *slog.Logger {
+	handler := &durationHandler{
+		Handler: l.logger.Handler(),
+		start:   start,
+	}
+	return slog.New(handler).With("request_id", requestID, "method", method)
+}
+
+func logFinished(ctx context.Context, logger *slog.Logger, err error) {
+	code := status.Code(err)
+	if err != nil {
+		logger.LogAttrs(ctx, slog.LevelWarn, "request failed", slog.String("code", code.String()), slog.String("error", err.Error()))
+		return
+	}
+	logger.LogAttrs(ctx, slog.LevelInfo, "request finished", slog.String("code", code.String()))
+}
+
+// requestIDFromContext returns the request ID sent by the client or a new
+// random one.
+func requestIDFromContext(ctx context.Context) string {
+	if md, ok := metadata.FromIncomingContext(ctx); ok {
+		if values := md.Get(RequestIDHeader); len(values) > 0 && values[0] != "" {
+			return values[0]
+		}
+	}
+	id := make([]byte, 16)
+	if _, err := rand.Read(id); err != nil {
+		return "unknown"
+	}
+	return hex.EncodeToString(id)
+}
+
+// durationHandler adds the time since the start of the request to every record.
+type durationHandler struct {
+	slog.Handler
+	start time.Time
+}
+
+func (h *durationHandler) Handle(ctx context.Context, r slog.Record) error {
+	r.AddAttrs(slog.Duration("duration", time.Since(h.start)))
+	return h.Handler.Handle(ctx, r)
+}
+
+func (h *durationHandler) WithAttrs(attrs []slog.Attr) slog.Handler {
+	return &durationHandler{Handler: h.Handler.WithAttrs(attrs), start: h.start}
+}
+
+func (h *durationHandler) WithGroup(name string) slog.Handler {
+	return &durationHandler{Handler: h.Handler.WithGroup(name), start: h.start}
+}
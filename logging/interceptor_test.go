@@ -0,0 +1,62 @@
+package logging_test
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"strings"
+	"testing"
+
+	"github.com/anchamber/genetics-tank/logging"
+	pb "github.com/anchamber/genetics-tank/proto"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/metadata"
+)
+
+func TestUnaryInterceptor(t *testing.T) {
+	var buffer bytes.Buffer
+	logger, err := logging.New(&buffer, "info")
+	if err != nil {
+		t.Fatalf("failed to create logger: %v", err)
+	}
+	requestLogger := logging.NewRequestLogger(logger)
+	ctx := metadata.NewIncomingContext(context.Background(), metadata.Pairs(logging.RequestIDHeader, "abc"))
+	info := &grpc.UnaryServerInfo{FullMethod: "/anchamber.genetics.TankService/GetTank"}
+
+	_, err = requestLogger.UnaryInterceptor(ctx, &pb.GetTankRequest{Number: 3}, info, func(ctx context.Context, req interface{}) (interface{}, error) {
+		logging.FromContext(ctx, nil).Info("get tank")
+		return nil, nil
+	})
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	lines := strings.Split(strings.TrimSpace(buffer.String()), "\n")
+	if len(lines) != 2 {
+		t.Fatalf("expected 2 log lines, actual: %d", len(lines))
+	}
+	for _, line := range lines {
+		var record map[string]interface{}
+		if err := json.Unmarshal([]byte(line), &record); err != nil {
+			t.Fatalf("log line is not json: %v", err)
+		}
+		if record["request_id"] != "abc" {
+			t.Errorf("request ids do not match, expected: %s | actual: %v", "abc", record["request_id"])
+		}
+		if record["method"] != info.FullMethod {
+			t.Errorf("methods do not match, expected: %s | actual: %v", info.FullMethod, record["method"])
+		}
+		if record["number"] != float64(3) {
+			t.Errorf("numbers do not match, expected: %d | actual: %v", 3, record["number"])
+		}
+		if _, ok := record["duration"]; !ok {
+			t.Errorf("duration missing in %s", line)
+		}
+	}
+}
+
+func TestNewWithInvalidLevel(t *testing.T) {
+	if _, err := logging.New(&bytes.Buffer{}, "verbose"); err == nil {
+		t.Errorf("invalid level should fail")
+	}
+}
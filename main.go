@@ -1,27 +1,260 @@
 package main
 
 import (
+	"context"
+	"crypto/tls"
 	"fmt"
+	"github.com/anchamber/genetics-tank/auth"
+	"github.com/anchamber/genetics-tank/certificate"
 	"github.com/anchamber/genetics-tank/db"
+	"github.com/anchamber/genetics-tank/healthcheck"
+	"github.com/anchamber/genetics-tank/label"
+	"github.com/anchamber/genetics-tank/logging"
+	"github.com/anchamber/genetics-tank/metrics"
 	pb "github.com/anchamber/genetics-tank/proto"
+	"github.com/anchamber/genetics-tank/ratelimit"
 	"github.com/anchamber/genetics-tank/service"
+	"github.com/anchamber/genetics-tank/tracing"
+	"github.com/grpc-ecosystem/grpc-gateway/v2/runtime"
 	"google.golang.org/grpc"
-	"log"
+	"google.golang.org/grpc/credentials"
+	"google.golang.org/grpc/credentials/insecure"
+	"google.golang.org/grpc/health"
+	healthpb "google.golang.org/grpc/health/grpc_health_v1"
+	"google.golang.org/grpc/reflection"
+	"log/slog"
 	"net"
+	"net/http"
+	"os"
+	"os/signal"
+	"strings"
+	"syscall"
+	"time"
 )
 
 func main() {
-	configuration := LoadConfiguration()
+	configPath := os.Getenv(ConfigFileEnv)
+	configuration, err := LoadConfiguration(configPath)
+	if err != nil {
+		fatal(slog.Default(), "failed to load configuration", err)
+	}
+
+	var logLevel slog.LevelVar
+	level, _ := logging.ParseLevel(configuration.LogLevel)
+	logLevel.Set(level)
+	logger := logging.NewLeveled(os.Stdout, &logLevel)
+	slog.SetDefault(logger)
+	logger.Info("loaded configuration", "file", configPath)
+	if !db.SearchSupported {
+		logger.Warn("full-text search is not available, SearchTanks returns Unimplemented", "reason", "SQLite was built without FTS5, build with -tags sqlite_fts5")
+	}
+
+	shutdownTracing, err := tracing.Setup(configuration.TraceExporter, configuration.TraceFile)
+	if err != nil {
+		fatal(logger, "failed to set up tracing", err)
+	}
+	defer func() {
+		if err := shutdownTracing(context.Background()); err != nil {
+			logger.Error("failed to flush traces", "error", err)
+		}
+	}()
 
 	addr := fmt.Sprintf(":%s", configuration.Port)
 	lis, err := net.Listen("tcp", addr)
 	if err != nil {
-		log.Fatalln("Failed to listen:", err)
+		fatal(logger, "failed to listen", err)
+	}
+	requestLogger := logging.NewRequestLogger(logger)
+	serviceMetrics := metrics.New()
+	authenticator := auth.New([]byte(configuration.AuthKey))
+	authenticator.CertificateRoles = configuration.CertificateRoles()
+	limiter := ratelimit.New(configuration.RateLimits())
+	serverOptions := []grpc.ServerOption{
+		grpc.ChainUnaryInterceptor(tracing.UnaryInterceptor, requestLogger.UnaryInterceptor, serviceMetrics.UnaryInterceptor, authenticator.UnaryInterceptor, limiter.UnaryInterceptor),
+		grpc.ChainStreamInterceptor(tracing.StreamInterceptor, requestLogger.StreamInterceptor, serviceMetrics.StreamInterceptor, authenticator.StreamInterceptor, limiter.StreamInterceptor),
+	}
+
+	var reloader *certificate.Reloader
+	if configuration.TLSCert != "" {
+		reloader, err = certificate.NewReloader(configuration.TLSCert, configuration.TLSKey, configuration.TLSClientCA)
+		if err != nil {
+			fatal(logger, "failed to load certificates", err)
+		}
+		reloader.Watch(10 * time.Second)
+		authenticator.TrustedProxy = reloader.IsServerCertificate
+		serverOptions = append(serverOptions, grpc.Creds(credentials.NewTLS(reloader.ServerConfig())))
+	}
+	s := grpc.NewServer(serverOptions...)
+	tankDB, err := db.NewMockDB(nil, logger)
+	if err != nil {
+		fatal(logger, "failed to create database", err)
+	}
+	if err := serviceMetrics.Register(metrics.NewTankCollector(tankDB)); err != nil {
+		fatal(logger, "failed to register metrics", err)
+	}
+	serviceDB := serviceMetrics.InstrumentDB(tankDB)
+	if configuration.CacheSize > 0 {
+		cachedDB := db.NewCachedDB(serviceDB, db.CacheOptions{Size: configuration.CacheSize, TTL: configuration.CacheTTL})
+		if err := serviceMetrics.Register(metrics.NewCacheCollector(cachedDB)); err != nil {
+			fatal(logger, "failed to register metrics", err)
+		}
+		serviceDB = cachedDB
 	}
-	s := grpc.NewServer()
-	pb.RegisterTankServiceServer(s, service.New(db.NewMockDB(nil)))
+	tankService := service.New(serviceDB, logger)
+	if configuration.PageTokenKey != "" {
+		tankService.SetPageTokenKey([]byte(configuration.PageTokenKey))
+	}
+	if configuration.LabelTemplates != "" {
+		templates, err := label.LoadTemplates(configuration.LabelTemplates)
+		if err != nil {
+			fatal(logger, "failed to load label templates", err)
+		}
+		tankService.SetLabelTemplates(templates)
+	}
+	pb.RegisterTankServiceServer(s, tankService)
+
+	healthServer := health.NewServer()
+	healthpb.RegisterHealthServer(s, healthServer)
+	reflection.Register(s)
+	checker := healthcheck.New(healthServer, tankDB, pb.TankService_ServiceDesc.ServiceName)
+	checker.Start(5 * time.Second)
+
+	watcher := NewConfigurationWatcher(configPath, configuration, func(configuration Configuration) {
+		level, _ := logging.ParseLevel(configuration.LogLevel)
+		logLevel.Set(level)
+		limiter.SetOptions(configuration.RateLimits())
+	})
+	watcher.Watch(10 * time.Second)
+	defer watcher.Stop()
+	hangup := make(chan os.Signal, 1)
+	signal.Notify(hangup, syscall.SIGHUP)
+	go func() {
+		for range hangup {
+			watcher.Reload()
+		}
+	}()
+
+	errs := make(chan error, 3)
+
+	// Serve Prometheus metrics
+	metricsMux := http.NewServeMux()
+	metricsMux.Handle("/metrics", serviceMetrics.Handler())
+	metricsServer := &http.Server{Addr: fmt.Sprintf(":%s", configuration.MetricsPort), Handler: metricsMux}
+	go func() {
+		logger.Info("starting metrics server", "addr", metricsServer.Addr)
+		errs <- fmt.Errorf("metrics server stopped: %w", metricsServer.ListenAndServe())
+	}()
+
+	// Serve REST/JSON gateway
+	gatewayCtx, cancelGateway := context.WithCancel(context.Background())
+	defer cancelGateway()
+	gateway, err := newGateway(gatewayCtx, fmt.Sprintf(":%s", configuration.GatewayPort), fmt.Sprintf("localhost:%s", configuration.Port), reloader)
+	if err != nil {
+		fatal(logger, "failed to create gRPC gateway", err)
+	}
+	go func() {
+		logger.Info("starting gRPC gateway", "addr", gateway.Addr)
+		if gateway.TLSConfig != nil {
+			errs <- fmt.Errorf("gateway stopped: %w", gateway.ListenAndServeTLS("", ""))
+			return
+		}
+		errs <- fmt.Errorf("gateway stopped: %w", gateway.ListenAndServe())
+	}()
 
 	// Serve gRPC Server
-	log.Printf("Starting gRPC server %s\n", addr)
-	log.Fatal(s.Serve(lis))
+	go func() {
+		logger.Info("starting gRPC server", "addr", addr)
+		errs <- fmt.Errorf("gRPC server stopped: %w", s.Serve(lis))
+	}()
+
+	ctx, stop := signal.NotifyContext(context.Background(), syscall.SIGINT, syscall.SIGTERM)
+	defer stop()
+	select {
+	case <-ctx.Done():
+		logger.Info("shutting down", "timeout", configuration.ShutdownTimeout.String())
+	case err := <-errs:
+		logger.Error("shutting down", "error", err)
+	}
+
+	// Report NOT_SERVING before the listener is closed
+	checker.Shutdown()
+	// The gateway proxies to the gRPC server, so it is stopped first
+	shutdownCtx, cancel := context.WithTimeout(context.Background(), configuration.ShutdownTimeout)
+	defer cancel()
+	if err := gateway.Shutdown(shutdownCtx); err != nil {
+		logger.Warn("in-flight gateway requests did not finish in time", "error", err)
+	}
+	if !gracefulStop(s, configuration.ShutdownTimeout) {
+		logger.Warn("in-flight requests did not finish in time, stopped forcefully")
+	}
+	if err := metricsServer.Shutdown(shutdownCtx); err != nil {
+		logger.Warn("failed to stop metrics server", "error", err)
+	}
+	if reloader != nil {
+		reloader.Stop()
+	}
+	if err := tankDB.Close(); err != nil {
+		logger.Error("failed to close database", "error", err)
+	}
+	logger.Info("shutdown complete")
+}
+
+// gracefulStop stops s from accepting new RPCs and waits for pending RPCs to
+// finish. If they do not finish within timeout, s is stopped forcefully and
+// false is returned.
+func gracefulStop(s *grpc.Server, timeout time.Duration) bool {
+	stopped := make(chan struct{})
+	go func() {
+		s.GracefulStop()
+		close(stopped)
+	}()
+	timer := time.NewTimer(timeout)
+	defer timer.Stop()
+	select {
+	case <-stopped:
+		return true
+	case <-timer.C:
+		s.Stop()
+		<-stopped
+		return false
+	}
+}
+
+func fatal(logger *slog.Logger, msg string, err error) {
+	logger.Error(msg, "error", err)
+	os.Exit(1)
+}
+
+// newGateway returns a server that exposes the TankService as REST/JSON on
+// addr by proxying every request to the gRPC server listening on endpoint
+// until ctx is done.
+// If reloader is set, the gateway has a TLS configuration with the
+// certificate of the gRPC server and uses it to connect to the gRPC server.
+func newGateway(ctx context.Context, addr string, endpoint string, reloader *certificate.Reloader) (*http.Server, error) {
+	mux := runtime.NewServeMux(runtime.WithIncomingHeaderMatcher(func(key string) (string, bool) {
+		if strings.EqualFold(key, logging.RequestIDHeader) {
+			return logging.RequestIDHeader, true
+		}
+		return runtime.DefaultHeaderMatcher(key)
+	}))
+	transportCredentials := insecure.NewCredentials()
+	if reloader != nil {
+		transportCredentials = credentials.NewTLS(reloader.LoopbackConfig())
+	}
+	opts := []grpc.DialOption{grpc.WithTransportCredentials(transportCredentials)}
+	err := pb.RegisterTankServiceHandlerFromEndpoint(ctx, mux, endpoint, opts)
+	if err != nil {
+		return nil, err
+	}
+	server := &http.Server{
+		Addr:    addr,
+		Handler: mux,
+	}
+	if reloader != nil {
+		server.TLSConfig = &tls.Config{
+			MinVersion:     tls.VersionTLS12,
+			GetCertificate: reloader.GetCertificate,
+		}
+	}
+	return server, nil
 }
@@ -0,0 +1,44 @@
+package metrics
+
+import (
+	"github.com/anchamber/genetics-tank/db"
+	"github.com/prometheus/client_golang/prometheus"
+)
+
+var (
+	cacheHitsDesc = prometheus.NewDesc(
+		prometheus.BuildFQName(namespace, "cache", "hits_total"),
+		"Number of cache hits by cache.", []string{"cache"}, nil)
+	cacheMissesDesc = prometheus.NewDesc(
+		prometheus.BuildFQName(namespace, "cache", "misses_total"),
+		"Number of cache misses by cache.", []string{"cache"}, nil)
+	cacheEvictionsDesc = prometheus.NewDesc(
+		prometheus.BuildFQName(namespace, "cache", "evictions_total"),
+		"Number of entries evicted from the cache.", nil, nil)
+)
+
+// CacheCollector exposes the hit and miss counters of a db.CachedDB.
+type CacheCollector struct {
+	cache *db.CachedDB
+}
+
+func NewCacheCollector(cache *db.CachedDB) *CacheCollector {
+	return &CacheCollector{
+		cache: cache,
+	}
+}
+
+func (c *CacheCollector) Describe(ch chan<- *prometheus.Desc) {
+	ch <- cacheHitsDesc
+	ch <- cacheMissesDesc
+	ch <- cacheEvictionsDesc
+}
+
+func (c *CacheCollector) Collect(ch chan<- prometheus.Metric) {
+	stats := c.cache.Stats()
+	ch <- prometheus.MustNewConstMetric(cacheHitsDesc, prometheus.CounterValue, float64(stats.TankHits), "tanks")
+	ch <- prometheus.MustNewConstMetric(cacheHitsDesc, prometheus.CounterValue, float64(stats.QueryHits), "queries")
+	ch <- prometheus.MustNewConstMetric(cacheMissesDesc, prometheus.CounterValue, float64(stats.TankMisses), "tanks")
+	ch <- prometheus.MustNewConstMetric(cacheMissesDesc, prometheus.CounterValue, float64(stats.QueryMisses), "queries")
+	ch <- prometheus.MustNewConstMetric(cacheEvictionsDesc, prometheus.CounterValue, float64(stats.Evictions))
+}
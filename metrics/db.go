@@ -0,0 +1,106 @@
+package metrics
+
+import (
+	"context"
+	"time"
+
+	"github.com/anchamber/genetics-tank/db"
+	"github.com/anchamber/genetics-tank/db/model"
+	"github.com/prometheus/client_golang/prometheus"
+)
+
+// InstrumentDB wraps tankDB to measure the latency of every operation. Wrap
+// the database below a cache, so cache hits are not measured as queries.
+func (m *Metrics) InstrumentDB(tankDB db.TankDB) db.TankDB {
+	return &instrumentedDB{
+		next:     tankDB,
+		duration: m.dbDuration,
+	}
+}
+
+type instrumentedDB struct {
+	next     db.TankDB
+	duration *prometheus.HistogramVec
+}
+
+func (i *instrumentedDB) observe(operation string, start time.Time, err error) {
+	result := "ok"
+	if err != nil {
+		result = "error"
+	}
+	i.duration.WithLabelValues(operation, result).Observe(time.Since(start).Seconds())
+}
+
+func (i *instrumentedDB) Select(ctx context.Context, options db.Options) ([]*model.Tank, error) {
+	start := time.Now()
+	tanks, err := i.next.Select(ctx, options)
+	i.observe("select", start, err)
+	return tanks, err
+}
+
+func (i *instrumentedDB) Count(ctx context.Context, options db.Options) (uint64, error) {
+	start := time.Now()
+	count, err := i.next.Count(ctx, options)
+	i.observe("count", start, err)
+	return count, err
+}
+
+func (i *instrumentedDB) SelectByNumber(ctx context.Context, number uint32, columns ...string) (*model.Tank, error) {
+	start := time.Now()
+	tank, err := i.next.SelectByNumber(ctx, number, columns...)
+	i.observe("select_by_number", start, err)
+	return tank, err
+}
+
+func (i *instrumentedDB) Search(ctx context.Context, query string, options db.Options) ([]*db.SearchResult, error) {
+	start := time.Now()
+	results, err := i.next.Search(ctx, query, options)
+	i.observe("search", start, err)
+	return results, err
+}
+
+func (i *instrumentedDB) Insert(ctx context.Context, tank *model.Tank) error {
+	start := time.Now()
+	err := i.next.Insert(ctx, tank)
+	i.observe("insert", start, err)
+	return err
+}
+
+func (i *instrumentedDB) Update(ctx context.Context, tank *model.Tank) error {
+	start := time.Now()
+	err := i.next.Update(ctx, tank)
+	i.observe("update", start, err)
+	return err
+}
+
+func (i *instrumentedDB) Apply(ctx context.Context, number uint32, change db.Change) (*model.Tank, error) {
+	start := time.Now()
+	tank, err := i.next.Apply(ctx, number, change)
+	i.observe("apply", start, err)
+	return tank, err
+}
+
+func (i *instrumentedDB) Delete(ctx context.Context, number uint32) error {
+	start := time.Now()
+	err := i.next.Delete(ctx, number)
+	i.observe("delete", start, err)
+	return err
+}
+
+func (i *instrumentedDB) CountBySystem(ctx context.Context) ([]db.SystemCount, error) {
+	start := time.Now()
+	counts, err := i.next.CountBySystem(ctx)
+	i.observe("count_by_system", start, err)
+	return counts, err
+}
+
+func (i *instrumentedDB) Ping(ctx context.Context) error {
+	start := time.Now()
+	err := i.next.Ping(ctx)
+	i.observe("ping", start, err)
+	return err
+}
+
+func (i *instrumentedDB) Close() error {
+	return i.next.Close()
+}
@@ -0,0 +1,79 @@
+package metrics_test
+
+import (
+	"context"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/anchamber/genetics-tank/db"
+	"github.com/anchamber/genetics-tank/db/model"
+	"github.com/anchamber/genetics-tank/metrics"
+	"github.com/prometheus/client_golang/prometheus/testutil"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+var testData = []*model.Tank{
+	{System: "A", Number: 1, Active: true, Size: 10, FishCount: 5},
+	{System: "A", Number: 2, Active: false, Size: 20, FishCount: 1},
+	{System: "B", Number: 3, Active: true, Size: 10, FishCount: 12},
+}
+
+func newMockDB(t testing.TB, tanks []*model.Tank) db.TankDBMock {
+	t.Helper()
+	tankDB, err := db.NewMockDB(tanks, nil)
+	if err != nil {
+		t.Fatalf("failed to create database: %v", err)
+	}
+	return tankDB
+}
+
+func TestTankCollector(t *testing.T) {
+	collector := metrics.NewTankCollector(newMockDB(t, testData))
+	expected := `
+# HELP tank_active_tanks Number of active tanks.
+# TYPE tank_active_tanks gauge
+tank_active_tanks 2
+# HELP tank_fish Number of fish by system.
+# TYPE tank_fish gauge
+tank_fish{system="A"} 6
+tank_fish{system="B"} 12
+# HELP tank_tanks Number of tanks.
+# TYPE tank_tanks gauge
+tank_tanks 3
+`
+	if err := testutil.CollectAndCompare(collector, strings.NewReader(expected)); err != nil {
+		t.Error(err)
+	}
+}
+
+func TestUnaryInterceptor(t *testing.T) {
+	m := metrics.New()
+	info := &grpc.UnaryServerInfo{FullMethod: "/anchamber.genetics.TankService/GetTank"}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return nil, status.Error(codes.NotFound, "not found")
+	}
+	for i := 0; i < 2; i++ {
+		_, _ = m.UnaryInterceptor(context.Background(), nil, info, handler)
+	}
+
+	tankDB := m.InstrumentDB(newMockDB(t, testData))
+	if _, err := tankDB.SelectByNumber(context.Background(), 1); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	recorder := httptest.NewRecorder()
+	m.Handler().ServeHTTP(recorder, httptest.NewRequest("GET", "/metrics", nil))
+	body := recorder.Body.String()
+	for _, line := range []string{
+		`tank_grpc_requests_total{code="NotFound",method="/anchamber.genetics.TankService/GetTank"} 2`,
+		`tank_grpc_request_duration_seconds_count{method="/anchamber.genetics.TankService/GetTank"} 2`,
+		`tank_db_query_duration_seconds_count{operation="select_by_number",result="ok"} 1`,
+	} {
+		if !strings.Contains(body, line) {
+			t.Errorf("metrics do not contain '%s'", line)
+		}
+	}
+}
@@ -0,0 +1,62 @@
+package metrics
+
+import (
+	"context"
+	"log/slog"
+	"time"
+
+	"github.com/anchamber/genetics-tank/db"
+	"github.com/prometheus/client_golang/prometheus"
+)
+
+var (
+	tanksDesc = prometheus.NewDesc(
+		prometheus.BuildFQName(namespace, "", "tanks"),
+		"Number of tanks.", nil, nil)
+	activeTanksDesc = prometheus.NewDesc(
+		prometheus.BuildFQName(namespace, "", "active_tanks"),
+		"Number of active tanks.", nil, nil)
+	fishDesc = prometheus.NewDesc(
+		prometheus.BuildFQName(namespace, "", "fish"),
+		"Number of fish by system.", []string{"system"}, nil)
+)
+
+// collectTimeout bounds the query of a scrape, so a slow scrape doesn't hold
+// the database connection.
+const collectTimeout = 5 * time.Second
+
+// TankCollector reads the business gauges from the database on every scrape.
+type TankCollector struct {
+	db db.TankDB
+}
+
+func NewTankCollector(tankDB db.TankDB) *TankCollector {
+	return &TankCollector{
+		db: tankDB,
+	}
+}
+
+func (c *TankCollector) Describe(ch chan<- *prometheus.Desc) {
+	ch <- tanksDesc
+	ch <- activeTanksDesc
+	ch <- fishDesc
+}
+
+func (c *TankCollector) Collect(ch chan<- prometheus.Metric) {
+	ctx, cancel := context.WithTimeout(context.Background(), collectTimeout)
+	defer cancel()
+	counts, err := c.db.CountBySystem(ctx)
+	if err != nil {
+		slog.Error("failed to collect tank metrics", "error", err)
+		ch <- prometheus.NewInvalidMetric(tanksDesc, err)
+		return
+	}
+	var tanks, active uint64
+	for _, system := range counts {
+		tanks += system.Tanks
+		active += system.Active
+		ch <- prometheus.MustNewConstMetric(fishDesc, prometheus.GaugeValue, float64(system.Fish), system.System)
+	}
+	ch <- prometheus.MustNewConstMetric(tanksDesc, prometheus.GaugeValue, float64(tanks))
+	ch <- prometheus.MustNewConstMetric(activeTanksDesc, prometheus.GaugeValue, float64(active))
+}
@@ -0,0 +1,101 @@
+package pagetoken
+
+import (
+	"bytes"
+	"crypto/hmac"
+	"crypto/rand"
+	"crypto/sha256"
+	"encoding/base64"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"strings"
+)
+
+var ErrInvalid = errors.New("invalid page token")
+
+// Signer creates and verifies opaque page tokens. A token holds the position
+// to continue a query from and is only valid for the query it was created
+// for.
+type Signer struct {
+	key []byte
+}
+
+// New creates a signer using key for HMAC-SHA256 signatures.
+func New(key []byte) *Signer {
+	return &Signer{key: key}
+}
+
+// NewRandom creates a signer with a random key. Its tokens are only valid
+// until the process restarts.
+func NewRandom() *Signer {
+	key := make([]byte, 32)
+	if _, err := rand.Read(key); err != nil {
+		panic(fmt.Sprintf("pagetoken: failed to generate key: %v", err))
+	}
+	return New(key)
+}
+
+type payload struct {
+	Query  []byte        `json:"q"`
+	Values []interface{} `json:"v"`
+}
+
+// Encode returns a token for the position values in the result of query.
+// query identifies the query, e.g. a hash of its filters and sort order.
+func (s *Signer) Encode(query []byte, values []interface{}) (string, error) {
+	data, err := json.Marshal(payload{Query: query, Values: values})
+	if err != nil {
+		return "", err
+	}
+	return encode(data) + "." + encode(s.sign(data)), nil
+}
+
+// Decode verifies token and returns the position stored in it. ErrInvalid is
+// returned if the token was not created by a signer with the same key or
+// for a different query.
+func (s *Signer) Decode(token string, query []byte) ([]interface{}, error) {
+	encodedData, encodedSignature, found := strings.Cut(token, ".")
+	if !found {
+		return nil, ErrInvalid
+	}
+	data, err := base64.RawURLEncoding.DecodeString(encodedData)
+	if err != nil {
+		return nil, ErrInvalid
+	}
+	signature, err := base64.RawURLEncoding.DecodeString(encodedSignature)
+	if err != nil || !hmac.Equal(signature, s.sign(data)) {
+		return nil, ErrInvalid
+	}
+	var p payload
+	decoder := json.NewDecoder(bytes.NewReader(data))
+	decoder.UseNumber()
+	if err := decoder.Decode(&p); err != nil || !bytes.Equal(p.Query, query) {
+		return nil, ErrInvalid
+	}
+	for i, value := range p.Values {
+		if number, ok := value.(json.Number); ok {
+			p.Values[i] = toNumber(number)
+		}
+	}
+	return p.Values, nil
+}
+
+// toNumber converts number to an int64 if possible or a float64 otherwise.
+func toNumber(number json.Number) interface{} {
+	if i, err := number.Int64(); err == nil {
+		return i
+	}
+	f, _ := number.Float64()
+	return f
+}
+
+func (s *Signer) sign(data []byte) []byte {
+	mac := hmac.New(sha256.New, s.key)
+	mac.Write(data)
+	return mac.Sum(nil)
+}
+
+func encode(data []byte) string {
+	return base64.RawURLEncoding.EncodeToString(data)
+}
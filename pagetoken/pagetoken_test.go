@@ -0,0 +1,48 @@
+package pagetoken_test
+
+import (
+	"errors"
+	"reflect"
+	"testing"
+
+	"github.com/anchamber/genetics-tank/pagetoken"
+)
+
+func TestSigner(t *testing.T) {
+	signer := pagetoken.New([]byte("secret"))
+	query := []byte("system = A")
+	token, err := signer.Encode(query, []interface{}{"A", uint32(3), true, int64(7)})
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	values, err := signer.Decode(token, query)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	expected := []interface{}{"A", int64(3), true, int64(7)}
+	if !reflect.DeepEqual(values, expected) {
+		t.Errorf("wrong values, expected: %v | actual: %v", expected, values)
+	}
+
+	other := pagetoken.NewRandom()
+	testCases := []struct {
+		name   string
+		signer *pagetoken.Signer
+		token  string
+		query  []byte
+	}{
+		{name: "different query", signer: signer, token: token, query: []byte("system = B")},
+		{name: "different key", signer: other, token: token, query: query},
+		{name: "tampered token", signer: signer, token: "x" + token, query: query},
+		{name: "garbage", signer: signer, token: "garbage", query: query},
+	}
+	for _, tc := range testCases {
+		tc := tc
+		t.Run(tc.name, func(t *testing.T) {
+			if _, err := tc.signer.Decode(tc.token, tc.query); !errors.Is(err, pagetoken.ErrInvalid) {
+				t.Errorf("expected invalid token, got: %v", err)
+			}
+		})
+	}
+}
@@ -1,6 +1,6 @@
 // Code generated by protoc-gen-go. DO NOT EDIT.
 // versions:
-// 	protoc-gen-go v1.25.0-devel
+// 	protoc-gen-go v1.27.1
 // 	protoc        v3.14.0
 // source: tank.proto
 
@@ -22,16 +22,173 @@ const (
 	_ = protoimpl.EnforceVersion(protoimpl.MaxVersion - 20)
 )
 
+type LabelFormat int32
+
+const (
+	// SVG renders one sheet of labels per page.
+	LabelFormat_SVG LabelFormat = 0
+	// PNG renders one sheet of labels per page.
+	LabelFormat_PNG LabelFormat = 1
+	// ZPL renders one label per page for thermal label printers.
+	LabelFormat_ZPL LabelFormat = 2
+)
+
+// Enum value maps for LabelFormat.
+var (
+	LabelFormat_name = map[int32]string{
+		0: "SVG",
+		1: "PNG",
+		2: "ZPL",
+	}
+	LabelFormat_value = map[string]int32{
+		"SVG": 0,
+		"PNG": 1,
+		"ZPL": 2,
+	}
+)
+
+func (x LabelFormat) Enum() *LabelFormat {
+	p := new(LabelFormat)
+	*p = x
+	return p
+}
+
+func (x LabelFormat) String() string {
+	return protoimpl.X.EnumStringOf(x.Descriptor(), protoreflect.EnumNumber(x))
+}
+
+func (LabelFormat) Descriptor() protoreflect.EnumDescriptor {
+	return file_tank_proto_enumTypes[0].Descriptor()
+}
+
+func (LabelFormat) Type() protoreflect.EnumType {
+	return &file_tank_proto_enumTypes[0]
+}
+
+func (x LabelFormat) Number() protoreflect.EnumNumber {
+	return protoreflect.EnumNumber(x)
+}
+
+// Deprecated: Use LabelFormat.Descriptor instead.
+func (LabelFormat) EnumDescriptor() ([]byte, []int) {
+	return file_tank_proto_rawDescGZIP(), []int{0}
+}
+
+type Symbology int32
+
+const (
+	Symbology_CODE128 Symbology = 0
+	Symbology_QR      Symbology = 1
+)
+
+// Enum value maps for Symbology.
+var (
+	Symbology_name = map[int32]string{
+		0: "CODE128",
+		1: "QR",
+	}
+	Symbology_value = map[string]int32{
+		"CODE128": 0,
+		"QR":      1,
+	}
+)
+
+func (x Symbology) Enum() *Symbology {
+	p := new(Symbology)
+	*p = x
+	return p
+}
+
+func (x Symbology) String() string {
+	return protoimpl.X.EnumStringOf(x.Descriptor(), protoreflect.EnumNumber(x))
+}
+
+func (Symbology) Descriptor() protoreflect.EnumDescriptor {
+	return file_tank_proto_enumTypes[1].Descriptor()
+}
+
+func (Symbology) Type() protoreflect.EnumType {
+	return &file_tank_proto_enumTypes[1]
+}
+
+func (x Symbology) Number() protoreflect.EnumNumber {
+	return protoreflect.EnumNumber(x)
+}
+
+// Deprecated: Use Symbology.Descriptor instead.
+func (Symbology) EnumDescriptor() ([]byte, []int) {
+	return file_tank_proto_rawDescGZIP(), []int{1}
+}
+
+type TankAction int32
+
+const (
+	TankAction_TANK_ACTION_UNSPECIFIED TankAction = 0
+	// CLEANED sets lastCleaned to the current time.
+	TankAction_CLEANED TankAction = 1
+	// FED sets lastFed to the current time.
+	TankAction_FED TankAction = 2
+	// MORTALITY removes count dead fish from fishCount.
+	TankAction_MORTALITY TankAction = 3
+)
+
+// Enum value maps for TankAction.
+var (
+	TankAction_name = map[int32]string{
+		0: "TANK_ACTION_UNSPECIFIED",
+		1: "CLEANED",
+		2: "FED",
+		3: "MORTALITY",
+	}
+	TankAction_value = map[string]int32{
+		"TANK_ACTION_UNSPECIFIED": 0,
+		"CLEANED":                 1,
+		"FED":                     2,
+		"MORTALITY":               3,
+	}
+)
+
+func (x TankAction) Enum() *TankAction {
+	p := new(TankAction)
+	*p = x
+	return p
+}
+
+func (x TankAction) String() string {
+	return protoimpl.X.EnumStringOf(x.Descriptor(), protoreflect.EnumNumber(x))
+}
+
+func (TankAction) Descriptor() protoreflect.EnumDescriptor {
+	return file_tank_proto_enumTypes[2].Descriptor()
+}
+
+func (TankAction) Type() protoreflect.EnumType {
+	return &file_tank_proto_enumTypes[2]
+}
+
+func (x TankAction) Number() protoreflect.EnumNumber {
+	return protoreflect.EnumNumber(x)
+}
+
+// Deprecated: Use TankAction.Descriptor instead.
+func (TankAction) EnumDescriptor() ([]byte, []int) {
+	return file_tank_proto_rawDescGZIP(), []int{2}
+}
+
 type Tank struct {
 	state         protoimpl.MessageState
 	sizeCache     protoimpl.SizeCache
 	unknownFields protoimpl.UnknownFields
 
-	System    string `protobuf:"bytes,1,opt,name=system,proto3" json:"system,omitempty"`
-	Number    uint32 `protobuf:"varint,2,opt,name=number,proto3" json:"number,omitempty"`
-	Active    bool   `protobuf:"varint,3,opt,name=active,proto3" json:"active,omitempty"`
-	Size      uint32 `protobuf:"varint,4,opt,name=size,proto3" json:"size,omitempty"`
-	FishCount uint32 `protobuf:"varint,5,opt,name=fishCount,proto3" json:"fishCount,omitempty"`
+	System      string `protobuf:"bytes,1,opt,name=system,proto3" json:"system,omitempty"`
+	Number      uint32 `protobuf:"varint,2,opt,name=number,proto3" json:"number,omitempty"`
+	Active      bool   `protobuf:"varint,3,opt,name=active,proto3" json:"active,omitempty"`
+	Size        uint32 `protobuf:"varint,4,opt,name=size,proto3" json:"size,omitempty"`
+	FishCount   uint32 `protobuf:"varint,5,opt,name=fishCount,proto3" json:"fishCount,omitempty"`
+	Lab         string `protobuf:"bytes,6,opt,name=lab,proto3" json:"lab,omitempty"`
+	Line        string `protobuf:"bytes,7,opt,name=line,proto3" json:"line,omitempty"`
+	Notes       string `protobuf:"bytes,8,opt,name=notes,proto3" json:"notes,omitempty"`
+	Responsible string `protobuf:"bytes,9,opt,name=responsible,proto3" json:"responsible,omitempty"`
 }
 
 func (x *Tank) Reset() {
@@ -101,6 +258,34 @@ func (x *Tank) GetFishCount() uint32 {
 	return 0
 }
 
+func (x *Tank) GetLab() string {
+	if x != nil {
+		return x.Lab
+	}
+	return ""
+}
+
+func (x *Tank) GetLine() string {
+	if x != nil {
+		return x.Line
+	}
+	return ""
+}
+
+func (x *Tank) GetNotes() string {
+	if x != nil {
+		return x.Notes
+	}
+	return ""
+}
+
+func (x *Tank) GetResponsible() string {
+	if x != nil {
+		return x.Responsible
+	}
+	return ""
+}
+
 type StreamTanksRequest struct {
 	state         protoimpl.MessageState
 	sizeCache     protoimpl.SizeCache
@@ -108,6 +293,19 @@ type StreamTanksRequest struct {
 
 	Filters     []*proto.Filter   `protobuf:"bytes,1,rep,name=filters,proto3" json:"filters,omitempty"`
 	Pageination *proto.Pagination `protobuf:"bytes,2,opt,name=pageination,proto3" json:"pageination,omitempty"`
+	// filter is combined with filters using AND.
+	Filter *FilterExpression `protobuf:"bytes,3,opt,name=filter,proto3" json:"filter,omitempty"`
+	// orderBy sorts the tanks by the given keys. Tanks are always sorted by
+	// number last.
+	OrderBy []*OrderBy `protobuf:"bytes,4,rep,name=orderBy,proto3" json:"orderBy,omitempty"`
+	// pageToken continues a previous request with the same filters and order.
+	// The token of the next page is returned in the x-next-page-token trailer.
+	PageToken string `protobuf:"bytes,5,opt,name=pageToken,proto3" json:"pageToken,omitempty"`
+	// pageSize limits the number of tanks when paging with page tokens.
+	PageSize uint32 `protobuf:"varint,6,opt,name=pageSize,proto3" json:"pageSize,omitempty"`
+	// readMask names the fields of TankResponse to return. All fields are
+	// returned if it is empty.
+	ReadMask *fieldmaskpb.FieldMask `protobuf:"bytes,7,opt,name=readMask,proto3" json:"readMask,omitempty"`
 }
 
 func (x *StreamTanksRequest) Reset() {
@@ -156,16 +354,52 @@ func (x *StreamTanksRequest) GetPageination() *proto.Pagination {
 	return nil
 }
 
-type GetTankRequest struct {
+func (x *StreamTanksRequest) GetFilter() *FilterExpression {
+	if x != nil {
+		return x.Filter
+	}
+	return nil
+}
+
+func (x *StreamTanksRequest) GetOrderBy() []*OrderBy {
+	if x != nil {
+		return x.OrderBy
+	}
+	return nil
+}
+
+func (x *StreamTanksRequest) GetPageToken() string {
+	if x != nil {
+		return x.PageToken
+	}
+	return ""
+}
+
+func (x *StreamTanksRequest) GetPageSize() uint32 {
+	if x != nil {
+		return x.PageSize
+	}
+	return 0
+}
+
+func (x *StreamTanksRequest) GetReadMask() *fieldmaskpb.FieldMask {
+	if x != nil {
+		return x.ReadMask
+	}
+	return nil
+}
+
+type CountTanksRequest struct {
 	state         protoimpl.MessageState
 	sizeCache     protoimpl.SizeCache
 	unknownFields protoimpl.UnknownFields
 
-	Number uint32 `protobuf:"varint,1,opt,name=number,proto3" json:"number,omitempty"`
+	Filters []*proto.Filter   `protobuf:"bytes,1,rep,name=filters,proto3" json:"filters,omitempty"`
+	Filter  *FilterExpression `protobuf:"bytes,2,opt,name=filter,proto3" json:"filter,omitempty"`
 }
 
-func (x *GetTankRequest) Reset() {
-	*x = GetTankRequest{}
+func (x *CountTanksRequest) Reset() {
+	*x = CountTanksRequest{}
 	if protoimpl.UnsafeEnabled {
 		mi := &file_tank_proto_msgTypes[2]
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
@@ -173,13 +407,13 @@ func (x *GetTankRequest) Reset() {
 	}
 }
 
-func (x *GetTankRequest) String() string {
+func (x *CountTanksRequest) String() string {
 	return protoimpl.X.MessageStringOf(x)
 }
 
-func (*GetTankRequest) ProtoMessage() {}
+func (*CountTanksRequest) ProtoMessage() {}
 
-func (x *GetTankRequest) ProtoReflect() protoreflect.Message {
+func (x *CountTanksRequest) ProtoReflect() protoreflect.Message {
 	mi := &file_tank_proto_msgTypes[2]
 	if protoimpl.UnsafeEnabled && x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
@@ -191,33 +425,35 @@ func (x *GetTankRequest) ProtoReflect() protoreflect.Message {
 	return mi.MessageOf(x)
 }
 
-// Deprecated: Use GetTankRequest.ProtoReflect.Descriptor instead.
-func (*GetTankRequest) Descriptor() ([]byte, []int) {
+// Deprecated: Use CountTanksRequest.ProtoReflect.Descriptor instead.
+func (*CountTanksRequest) Descriptor() ([]byte, []int) {
 	return file_tank_proto_rawDescGZIP(), []int{2}
 }
 
-func (x *GetTankRequest) GetNumber() uint32 {
+func (x *CountTanksRequest) GetFilters() []*proto.Filter {
 	if x != nil {
-		return x.Number
+		return x.Filters
 	}
-	return 0
+	return nil
 }
 
-type TankResponse struct {
+func (x *CountTanksRequest) GetFilter() *FilterExpression {
+	if x != nil {
+		return x.Filter
+	}
+	return nil
+}
+
+type CountTanksResponse struct {
 	state         protoimpl.MessageState
 	sizeCache     protoimpl.SizeCache
 	unknownFields protoimpl.UnknownFields
 
-	Id        int64  `protobuf:"varint,1,opt,name=id,proto3" json:"id,omitempty"`
-	System    string `protobuf:"bytes,2,opt,name=system,proto3" json:"system,omitempty"`
-	Number    uint32 `protobuf:"varint,3,opt,name=number,proto3" json:"number,omitempty"`
-	Active    bool   `protobuf:"varint,4,opt,name=active,proto3" json:"active,omitempty"`
-	Size      uint32 `protobuf:"varint,5,opt,name=size,proto3" json:"size,omitempty"`
-	FishCount uint32 `protobuf:"varint,6,opt,name=fishCount,proto3" json:"fishCount,omitempty"`
+	Count uint64 `protobuf:"varint,1,opt,name=count,proto3" json:"count,omitempty"`
 }
 
-func (x *TankResponse) Reset() {
-	*x = TankResponse{}
+func (x *CountTanksResponse) Reset() {
+	*x = CountTanksResponse{}
 	if protoimpl.UnsafeEnabled {
 		mi := &file_tank_proto_msgTypes[3]
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
@@ -225,13 +461,13 @@ func (x *TankResponse) Reset() {
 	}
 }
 
-func (x *TankResponse) String() string {
+func (x *CountTanksResponse) String() string {
 	return protoimpl.X.MessageStringOf(x)
 }
 
-func (*TankResponse) ProtoMessage() {}
+func (*CountTanksResponse) ProtoMessage() {}
 
-func (x *TankResponse) ProtoReflect() protoreflect.Message {
+func (x *CountTanksResponse) ProtoReflect() protoreflect.Message {
 	mi := &file_tank_proto_msgTypes[3]
 	if protoimpl.UnsafeEnabled && x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
@@ -243,76 +479,101 @@ func (x *TankResponse) ProtoReflect() protoreflect.Message {
 	return mi.MessageOf(x)
 }
 
-// Deprecated: Use TankResponse.ProtoReflect.Descriptor instead.
-func (*TankResponse) Descriptor() ([]byte, []int) {
+// Deprecated: Use CountTanksResponse.ProtoReflect.Descriptor instead.
+func (*CountTanksResponse) Descriptor() ([]byte, []int) {
 	return file_tank_proto_rawDescGZIP(), []int{3}
 }
 
-func (x *TankResponse) GetId() int64 {
+func (x *CountTanksResponse) GetCount() uint64 {
 	if x != nil {
-		return x.Id
+		return x.Count
 	}
 	return 0
 }
 
-func (x *TankResponse) GetSystem() string {
-	if x != nil {
-		return x.System
-	}
-	return ""
+type SearchTanksRequest struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	// query is split into words, every word has to be the prefix of a word of
+	// a matching tank.
+	Query string `protobuf:"bytes,1,opt,name=query,proto3" json:"query,omitempty"`
+	// limit defaults to 20 and is capped at 100.
+	Limit uint32 `protobuf:"varint,2,opt,name=limit,proto3" json:"limit,omitempty"`
 }
 
-func (x *TankResponse) GetNumber() uint32 {
-	if x != nil {
-		return x.Number
+func (x *SearchTanksRequest) Reset() {
+	*x = SearchTanksRequest{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_tank_proto_msgTypes[4]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
 	}
-	return 0
 }
 
-func (x *TankResponse) GetActive() bool {
-	if x != nil {
-		return x.Active
+func (x *SearchTanksRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*SearchTanksRequest) ProtoMessage() {}
+
+func (x *SearchTanksRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_tank_proto_msgTypes[4]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
 	}
-	return false
+	return mi.MessageOf(x)
 }
 
-func (x *TankResponse) GetSize() uint32 {
+// Deprecated: Use SearchTanksRequest.ProtoReflect.Descriptor instead.
+func (*SearchTanksRequest) Descriptor() ([]byte, []int) {
+	return file_tank_proto_rawDescGZIP(), []int{4}
+}
+
+func (x *SearchTanksRequest) GetQuery() string {
 	if x != nil {
-		return x.Size
+		return x.Query
 	}
-	return 0
+	return ""
 }
 
-func (x *TankResponse) GetFishCount() uint32 {
+func (x *SearchTanksRequest) GetLimit() uint32 {
 	if x != nil {
-		return x.FishCount
+		return x.Limit
 	}
 	return 0
 }
 
-type GetTankStatsRequest struct {
+type SearchTanksResponse struct {
 	state         protoimpl.MessageState
 	sizeCache     protoimpl.SizeCache
 	unknownFields protoimpl.UnknownFields
+
+	Results []*SearchResult `protobuf:"bytes,1,rep,name=results,proto3" json:"results,omitempty"`
 }
 
-func (x *GetTankStatsRequest) Reset() {
-	*x = GetTankStatsRequest{}
+func (x *SearchTanksResponse) Reset() {
+	*x = SearchTanksResponse{}
 	if protoimpl.UnsafeEnabled {
-		mi := &file_tank_proto_msgTypes[4]
+		mi := &file_tank_proto_msgTypes[5]
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		ms.StoreMessageInfo(mi)
 	}
 }
 
-func (x *GetTankStatsRequest) String() string {
+func (x *SearchTanksResponse) String() string {
 	return protoimpl.X.MessageStringOf(x)
 }
 
-func (*GetTankStatsRequest) ProtoMessage() {}
+func (*SearchTanksResponse) ProtoMessage() {}
 
-func (x *GetTankStatsRequest) ProtoReflect() protoreflect.Message {
-	mi := &file_tank_proto_msgTypes[4]
+func (x *SearchTanksResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_tank_proto_msgTypes[5]
 	if protoimpl.UnsafeEnabled && x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -323,38 +584,48 @@ func (x *GetTankStatsRequest) ProtoReflect() protoreflect.Message {
 	return mi.MessageOf(x)
 }
 
-// Deprecated: Use GetTankStatsRequest.ProtoReflect.Descriptor instead.
-func (*GetTankStatsRequest) Descriptor() ([]byte, []int) {
-	return file_tank_proto_rawDescGZIP(), []int{4}
+// Deprecated: Use SearchTanksResponse.ProtoReflect.Descriptor instead.
+func (*SearchTanksResponse) Descriptor() ([]byte, []int) {
+	return file_tank_proto_rawDescGZIP(), []int{5}
 }
 
-type GetTankStatsResponse struct {
+func (x *SearchTanksResponse) GetResults() []*SearchResult {
+	if x != nil {
+		return x.Results
+	}
+	return nil
+}
+
+type SearchResult struct {
 	state         protoimpl.MessageState
 	sizeCache     protoimpl.SizeCache
 	unknownFields protoimpl.UnknownFields
 
-	CountOverall          int64 `protobuf:"varint,1,opt,name=countOverall,proto3" json:"countOverall,omitempty"`
-	CountCleaningSoon     int64 `protobuf:"varint,2,opt,name=countCleaningSoon,proto3" json:"countCleaningSoon,omitempty"`
-	CountCleaningRequired int64 `protobuf:"varint,3,opt,name=countCleaningRequired,proto3" json:"countCleaningRequired,omitempty"`
+	Tank *TankResponse `protobuf:"bytes,1,opt,name=tank,proto3" json:"tank,omitempty"`
+	// rank is lower for better matches.
+	Rank float64 `protobuf:"fixed64,2,opt,name=rank,proto3" json:"rank,omitempty"`
+	// snippet is an excerpt of the best matching field with the matching
+	// words enclosed in [ and ].
+	Snippet string `protobuf:"bytes,3,opt,name=snippet,proto3" json:"snippet,omitempty"`
 }
 
-func (x *GetTankStatsResponse) Reset() {
-	*x = GetTankStatsResponse{}
+func (x *SearchResult) Reset() {
+	*x = SearchResult{}
 	if protoimpl.UnsafeEnabled {
-		mi := &file_tank_proto_msgTypes[5]
+		mi := &file_tank_proto_msgTypes[6]
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		ms.StoreMessageInfo(mi)
 	}
 }
 
-func (x *GetTankStatsResponse) String() string {
+func (x *SearchResult) String() string {
 	return protoimpl.X.MessageStringOf(x)
 }
 
-func (*GetTankStatsResponse) ProtoMessage() {}
+func (*SearchResult) ProtoMessage() {}
 
-func (x *GetTankStatsResponse) ProtoReflect() protoreflect.Message {
-	mi := &file_tank_proto_msgTypes[5]
+func (x *SearchResult) ProtoReflect() protoreflect.Message {
+	mi := &file_tank_proto_msgTypes[6]
 	if protoimpl.UnsafeEnabled && x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -365,61 +636,58 @@ func (x *GetTankStatsResponse) ProtoReflect() protoreflect.Message {
 	return mi.MessageOf(x)
 }
 
-// Deprecated: Use GetTankStatsResponse.ProtoReflect.Descriptor instead.
-func (*GetTankStatsResponse) Descriptor() ([]byte, []int) {
-	return file_tank_proto_rawDescGZIP(), []int{5}
+// Deprecated: Use SearchResult.ProtoReflect.Descriptor instead.
+func (*SearchResult) Descriptor() ([]byte, []int) {
+	return file_tank_proto_rawDescGZIP(), []int{6}
 }
 
-func (x *GetTankStatsResponse) GetCountOverall() int64 {
+func (x *SearchResult) GetTank() *TankResponse {
 	if x != nil {
-		return x.CountOverall
+		return x.Tank
 	}
-	return 0
+	return nil
 }
 
-func (x *GetTankStatsResponse) GetCountCleaningSoon() int64 {
+func (x *SearchResult) GetRank() float64 {
 	if x != nil {
-		return x.CountCleaningSoon
+		return x.Rank
 	}
 	return 0
 }
 
-func (x *GetTankStatsResponse) GetCountCleaningRequired() int64 {
+func (x *SearchResult) GetSnippet() string {
 	if x != nil {
-		return x.CountCleaningRequired
+		return x.Snippet
 	}
-	return 0
+	return ""
 }
 
-type CreateTankRequest struct {
+type OrderBy struct {
 	state         protoimpl.MessageState
 	sizeCache     protoimpl.SizeCache
 	unknownFields protoimpl.UnknownFields
 
-	System    string `protobuf:"bytes,1,opt,name=system,proto3" json:"system,omitempty"`
-	Number    uint32 `protobuf:"varint,2,opt,name=number,proto3" json:"number,omitempty"`
-	Active    bool   `protobuf:"varint,3,opt,name=active,proto3" json:"active,omitempty"`
-	Size      uint32 `protobuf:"varint,4,opt,name=size,proto3" json:"size,omitempty"`
-	FishCount uint32 `protobuf:"varint,5,opt,name=fishCount,proto3" json:"fishCount,omitempty"`
+	Key        string `protobuf:"bytes,1,opt,name=key,proto3" json:"key,omitempty"`
+	Descending bool   `protobuf:"varint,2,opt,name=descending,proto3" json:"descending,omitempty"`
 }
 
-func (x *CreateTankRequest) Reset() {
-	*x = CreateTankRequest{}
+func (x *OrderBy) Reset() {
+	*x = OrderBy{}
 	if protoimpl.UnsafeEnabled {
-		mi := &file_tank_proto_msgTypes[6]
+		mi := &file_tank_proto_msgTypes[7]
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		ms.StoreMessageInfo(mi)
 	}
 }
 
-func (x *CreateTankRequest) String() string {
+func (x *OrderBy) String() string {
 	return protoimpl.X.MessageStringOf(x)
 }
 
-func (*CreateTankRequest) ProtoMessage() {}
+func (*OrderBy) ProtoMessage() {}
 
-func (x *CreateTankRequest) ProtoReflect() protoreflect.Message {
-	mi := &file_tank_proto_msgTypes[6]
+func (x *OrderBy) ProtoReflect() protoreflect.Message {
+	mi := &file_tank_proto_msgTypes[7]
 	if protoimpl.UnsafeEnabled && x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -430,33 +698,754 @@ func (x *CreateTankRequest) ProtoReflect() protoreflect.Message {
 	return mi.MessageOf(x)
 }
 
-// Deprecated: Use CreateTankRequest.ProtoReflect.Descriptor instead.
-func (*CreateTankRequest) Descriptor() ([]byte, []int) {
-	return file_tank_proto_rawDescGZIP(), []int{6}
+// Deprecated: Use OrderBy.ProtoReflect.Descriptor instead.
+func (*OrderBy) Descriptor() ([]byte, []int) {
+	return file_tank_proto_rawDescGZIP(), []int{7}
 }
 
-func (x *CreateTankRequest) GetSystem() string {
+func (x *OrderBy) GetKey() string {
 	if x != nil {
-		return x.System
+		return x.Key
 	}
 	return ""
 }
 
-func (x *CreateTankRequest) GetNumber() uint32 {
-	if x != nil {
-		return x.Number
-	}
-	return 0
-}
-
-func (x *CreateTankRequest) GetActive() bool {
+func (x *OrderBy) GetDescending() bool {
 	if x != nil {
-		return x.Active
+		return x.Descending
 	}
 	return false
 }
 
-func (x *CreateTankRequest) GetSize() uint32 {
+// FilterExpression is a boolean expression over the fields of a tank.
+type FilterExpression struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	// Types that are assignable to Expression:
+	//	*FilterExpression_And
+	//	*FilterExpression_Or
+	//	*FilterExpression_Not
+	//	*FilterExpression_Condition
+	//	*FilterExpression_In
+	//	*FilterExpression_Between
+	//	*FilterExpression_IsNull
+	Expression isFilterExpression_Expression `protobuf_oneof:"expression"`
+}
+
+func (x *FilterExpression) Reset() {
+	*x = FilterExpression{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_tank_proto_msgTypes[8]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *FilterExpression) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*FilterExpression) ProtoMessage() {}
+
+func (x *FilterExpression) ProtoReflect() protoreflect.Message {
+	mi := &file_tank_proto_msgTypes[8]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use FilterExpression.ProtoReflect.Descriptor instead.
+func (*FilterExpression) Descriptor() ([]byte, []int) {
+	return file_tank_proto_rawDescGZIP(), []int{8}
+}
+
+func (m *FilterExpression) GetExpression() isFilterExpression_Expression {
+	if m != nil {
+		return m.Expression
+	}
+	return nil
+}
+
+func (x *FilterExpression) GetAnd() *FilterExpressionList {
+	if x, ok := x.GetExpression().(*FilterExpression_And); ok {
+		return x.And
+	}
+	return nil
+}
+
+func (x *FilterExpression) GetOr() *FilterExpressionList {
+	if x, ok := x.GetExpression().(*FilterExpression_Or); ok {
+		return x.Or
+	}
+	return nil
+}
+
+func (x *FilterExpression) GetNot() *FilterExpression {
+	if x, ok := x.GetExpression().(*FilterExpression_Not); ok {
+		return x.Not
+	}
+	return nil
+}
+
+func (x *FilterExpression) GetCondition() *proto.Filter {
+	if x, ok := x.GetExpression().(*FilterExpression_Condition); ok {
+		return x.Condition
+	}
+	return nil
+}
+
+func (x *FilterExpression) GetIn() *InFilter {
+	if x, ok := x.GetExpression().(*FilterExpression_In); ok {
+		return x.In
+	}
+	return nil
+}
+
+func (x *FilterExpression) GetBetween() *BetweenFilter {
+	if x, ok := x.GetExpression().(*FilterExpression_Between); ok {
+		return x.Between
+	}
+	return nil
+}
+
+func (x *FilterExpression) GetIsNull() *IsNullFilter {
+	if x, ok := x.GetExpression().(*FilterExpression_IsNull); ok {
+		return x.IsNull
+	}
+	return nil
+}
+
+type isFilterExpression_Expression interface {
+	isFilterExpression_Expression()
+}
+
+type FilterExpression_And struct {
+	And *FilterExpressionList `protobuf:"bytes,1,opt,name=and,proto3,oneof"`
+}
+
+type FilterExpression_Or struct {
+	Or *FilterExpressionList `protobuf:"bytes,2,opt,name=or,proto3,oneof"`
+}
+
+type FilterExpression_Not struct {
+	Not *FilterExpression `protobuf:"bytes,3,opt,name=not,proto3,oneof"`
+}
+
+type FilterExpression_Condition struct {
+	Condition *proto.Filter `protobuf:"bytes,4,opt,name=condition,proto3,oneof"`
+}
+
+type FilterExpression_In struct {
+	In *InFilter `protobuf:"bytes,5,opt,name=in,proto3,oneof"`
+}
+
+type FilterExpression_Between struct {
+	Between *BetweenFilter `protobuf:"bytes,6,opt,name=between,proto3,oneof"`
+}
+
+type FilterExpression_IsNull struct {
+	IsNull *IsNullFilter `protobuf:"bytes,7,opt,name=isNull,proto3,oneof"`
+}
+
+func (*FilterExpression_And) isFilterExpression_Expression() {}
+
+func (*FilterExpression_Or) isFilterExpression_Expression() {}
+
+func (*FilterExpression_Not) isFilterExpression_Expression() {}
+
+func (*FilterExpression_Condition) isFilterExpression_Expression() {}
+
+func (*FilterExpression_In) isFilterExpression_Expression() {}
+
+func (*FilterExpression_Between) isFilterExpression_Expression() {}
+
+func (*FilterExpression_IsNull) isFilterExpression_Expression() {}
+
+type FilterExpressionList struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	Expressions []*FilterExpression `protobuf:"bytes,1,rep,name=expressions,proto3" json:"expressions,omitempty"`
+}
+
+func (x *FilterExpressionList) Reset() {
+	*x = FilterExpressionList{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_tank_proto_msgTypes[9]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *FilterExpressionList) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*FilterExpressionList) ProtoMessage() {}
+
+func (x *FilterExpressionList) ProtoReflect() protoreflect.Message {
+	mi := &file_tank_proto_msgTypes[9]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use FilterExpressionList.ProtoReflect.Descriptor instead.
+func (*FilterExpressionList) Descriptor() ([]byte, []int) {
+	return file_tank_proto_rawDescGZIP(), []int{9}
+}
+
+func (x *FilterExpressionList) GetExpressions() []*FilterExpression {
+	if x != nil {
+		return x.Expressions
+	}
+	return nil
+}
+
+// InFilter matches tanks where key equals one of the values.
+type InFilter struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	Key    string   `protobuf:"bytes,1,opt,name=key,proto3" json:"key,omitempty"`
+	Values []string `protobuf:"bytes,2,rep,name=values,proto3" json:"values,omitempty"`
+}
+
+func (x *InFilter) Reset() {
+	*x = InFilter{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_tank_proto_msgTypes[10]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *InFilter) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*InFilter) ProtoMessage() {}
+
+func (x *InFilter) ProtoReflect() protoreflect.Message {
+	mi := &file_tank_proto_msgTypes[10]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use InFilter.ProtoReflect.Descriptor instead.
+func (*InFilter) Descriptor() ([]byte, []int) {
+	return file_tank_proto_rawDescGZIP(), []int{10}
+}
+
+func (x *InFilter) GetKey() string {
+	if x != nil {
+		return x.Key
+	}
+	return ""
+}
+
+func (x *InFilter) GetValues() []string {
+	if x != nil {
+		return x.Values
+	}
+	return nil
+}
+
+// BetweenFilter matches tanks where key is between lower and upper, inclusive.
+type BetweenFilter struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	Key   string `protobuf:"bytes,1,opt,name=key,proto3" json:"key,omitempty"`
+	Lower string `protobuf:"bytes,2,opt,name=lower,proto3" json:"lower,omitempty"`
+	Upper string `protobuf:"bytes,3,opt,name=upper,proto3" json:"upper,omitempty"`
+}
+
+func (x *BetweenFilter) Reset() {
+	*x = BetweenFilter{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_tank_proto_msgTypes[11]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *BetweenFilter) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*BetweenFilter) ProtoMessage() {}
+
+func (x *BetweenFilter) ProtoReflect() protoreflect.Message {
+	mi := &file_tank_proto_msgTypes[11]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use BetweenFilter.ProtoReflect.Descriptor instead.
+func (*BetweenFilter) Descriptor() ([]byte, []int) {
+	return file_tank_proto_rawDescGZIP(), []int{11}
+}
+
+func (x *BetweenFilter) GetKey() string {
+	if x != nil {
+		return x.Key
+	}
+	return ""
+}
+
+func (x *BetweenFilter) GetLower() string {
+	if x != nil {
+		return x.Lower
+	}
+	return ""
+}
+
+func (x *BetweenFilter) GetUpper() string {
+	if x != nil {
+		return x.Upper
+	}
+	return ""
+}
+
+type IsNullFilter struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	Key string `protobuf:"bytes,1,opt,name=key,proto3" json:"key,omitempty"`
+}
+
+func (x *IsNullFilter) Reset() {
+	*x = IsNullFilter{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_tank_proto_msgTypes[12]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *IsNullFilter) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*IsNullFilter) ProtoMessage() {}
+
+func (x *IsNullFilter) ProtoReflect() protoreflect.Message {
+	mi := &file_tank_proto_msgTypes[12]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use IsNullFilter.ProtoReflect.Descriptor instead.
+func (*IsNullFilter) Descriptor() ([]byte, []int) {
+	return file_tank_proto_rawDescGZIP(), []int{12}
+}
+
+func (x *IsNullFilter) GetKey() string {
+	if x != nil {
+		return x.Key
+	}
+	return ""
+}
+
+type GetTankRequest struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	Number uint32 `protobuf:"varint,1,opt,name=number,proto3" json:"number,omitempty"`
+	// readMask names the fields of TankResponse to return. All fields are
+	// returned if it is empty.
+	ReadMask *fieldmaskpb.FieldMask `protobuf:"bytes,2,opt,name=readMask,proto3" json:"readMask,omitempty"`
+}
+
+func (x *GetTankRequest) Reset() {
+	*x = GetTankRequest{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_tank_proto_msgTypes[13]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *GetTankRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*GetTankRequest) ProtoMessage() {}
+
+func (x *GetTankRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_tank_proto_msgTypes[13]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use GetTankRequest.ProtoReflect.Descriptor instead.
+func (*GetTankRequest) Descriptor() ([]byte, []int) {
+	return file_tank_proto_rawDescGZIP(), []int{13}
+}
+
+func (x *GetTankRequest) GetNumber() uint32 {
+	if x != nil {
+		return x.Number
+	}
+	return 0
+}
+
+func (x *GetTankRequest) GetReadMask() *fieldmaskpb.FieldMask {
+	if x != nil {
+		return x.ReadMask
+	}
+	return nil
+}
+
+type TankResponse struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	Id          int64  `protobuf:"varint,1,opt,name=id,proto3" json:"id,omitempty"`
+	System      string `protobuf:"bytes,2,opt,name=system,proto3" json:"system,omitempty"`
+	Number      uint32 `protobuf:"varint,3,opt,name=number,proto3" json:"number,omitempty"`
+	Active      bool   `protobuf:"varint,4,opt,name=active,proto3" json:"active,omitempty"`
+	Size        uint32 `protobuf:"varint,5,opt,name=size,proto3" json:"size,omitempty"`
+	FishCount   uint32 `protobuf:"varint,6,opt,name=fishCount,proto3" json:"fishCount,omitempty"`
+	Lab         string `protobuf:"bytes,7,opt,name=lab,proto3" json:"lab,omitempty"`
+	Line        string `protobuf:"bytes,8,opt,name=line,proto3" json:"line,omitempty"`
+	Notes       string `protobuf:"bytes,9,opt,name=notes,proto3" json:"notes,omitempty"`
+	Responsible string `protobuf:"bytes,10,opt,name=responsible,proto3" json:"responsible,omitempty"`
+	// lastCleaned and lastFed are Unix times in seconds, 0 if unknown.
+	LastCleaned int64 `protobuf:"varint,11,opt,name=lastCleaned,proto3" json:"lastCleaned,omitempty"`
+	LastFed     int64 `protobuf:"varint,12,opt,name=lastFed,proto3" json:"lastFed,omitempty"`
+}
+
+func (x *TankResponse) Reset() {
+	*x = TankResponse{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_tank_proto_msgTypes[14]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *TankResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*TankResponse) ProtoMessage() {}
+
+func (x *TankResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_tank_proto_msgTypes[14]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use TankResponse.ProtoReflect.Descriptor instead.
+func (*TankResponse) Descriptor() ([]byte, []int) {
+	return file_tank_proto_rawDescGZIP(), []int{14}
+}
+
+func (x *TankResponse) GetId() int64 {
+	if x != nil {
+		return x.Id
+	}
+	return 0
+}
+
+func (x *TankResponse) GetSystem() string {
+	if x != nil {
+		return x.System
+	}
+	return ""
+}
+
+func (x *TankResponse) GetNumber() uint32 {
+	if x != nil {
+		return x.Number
+	}
+	return 0
+}
+
+func (x *TankResponse) GetActive() bool {
+	if x != nil {
+		return x.Active
+	}
+	return false
+}
+
+func (x *TankResponse) GetSize() uint32 {
+	if x != nil {
+		return x.Size
+	}
+	return 0
+}
+
+func (x *TankResponse) GetFishCount() uint32 {
+	if x != nil {
+		return x.FishCount
+	}
+	return 0
+}
+
+func (x *TankResponse) GetLab() string {
+	if x != nil {
+		return x.Lab
+	}
+	return ""
+}
+
+func (x *TankResponse) GetLine() string {
+	if x != nil {
+		return x.Line
+	}
+	return ""
+}
+
+func (x *TankResponse) GetNotes() string {
+	if x != nil {
+		return x.Notes
+	}
+	return ""
+}
+
+func (x *TankResponse) GetResponsible() string {
+	if x != nil {
+		return x.Responsible
+	}
+	return ""
+}
+
+func (x *TankResponse) GetLastCleaned() int64 {
+	if x != nil {
+		return x.LastCleaned
+	}
+	return 0
+}
+
+func (x *TankResponse) GetLastFed() int64 {
+	if x != nil {
+		return x.LastFed
+	}
+	return 0
+}
+
+type GetTankStatsRequest struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+}
+
+func (x *GetTankStatsRequest) Reset() {
+	*x = GetTankStatsRequest{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_tank_proto_msgTypes[15]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *GetTankStatsRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*GetTankStatsRequest) ProtoMessage() {}
+
+func (x *GetTankStatsRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_tank_proto_msgTypes[15]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use GetTankStatsRequest.ProtoReflect.Descriptor instead.
+func (*GetTankStatsRequest) Descriptor() ([]byte, []int) {
+	return file_tank_proto_rawDescGZIP(), []int{15}
+}
+
+type GetTankStatsResponse struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	CountOverall          int64 `protobuf:"varint,1,opt,name=countOverall,proto3" json:"countOverall,omitempty"`
+	CountCleaningSoon     int64 `protobuf:"varint,2,opt,name=countCleaningSoon,proto3" json:"countCleaningSoon,omitempty"`
+	CountCleaningRequired int64 `protobuf:"varint,3,opt,name=countCleaningRequired,proto3" json:"countCleaningRequired,omitempty"`
+}
+
+func (x *GetTankStatsResponse) Reset() {
+	*x = GetTankStatsResponse{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_tank_proto_msgTypes[16]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *GetTankStatsResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*GetTankStatsResponse) ProtoMessage() {}
+
+func (x *GetTankStatsResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_tank_proto_msgTypes[16]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use GetTankStatsResponse.ProtoReflect.Descriptor instead.
+func (*GetTankStatsResponse) Descriptor() ([]byte, []int) {
+	return file_tank_proto_rawDescGZIP(), []int{16}
+}
+
+func (x *GetTankStatsResponse) GetCountOverall() int64 {
+	if x != nil {
+		return x.CountOverall
+	}
+	return 0
+}
+
+func (x *GetTankStatsResponse) GetCountCleaningSoon() int64 {
+	if x != nil {
+		return x.CountCleaningSoon
+	}
+	return 0
+}
+
+func (x *GetTankStatsResponse) GetCountCleaningRequired() int64 {
+	if x != nil {
+		return x.CountCleaningRequired
+	}
+	return 0
+}
+
+type CreateTankRequest struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	System      string `protobuf:"bytes,1,opt,name=system,proto3" json:"system,omitempty"`
+	Number      uint32 `protobuf:"varint,2,opt,name=number,proto3" json:"number,omitempty"`
+	Active      bool   `protobuf:"varint,3,opt,name=active,proto3" json:"active,omitempty"`
+	Size        uint32 `protobuf:"varint,4,opt,name=size,proto3" json:"size,omitempty"`
+	FishCount   uint32 `protobuf:"varint,5,opt,name=fishCount,proto3" json:"fishCount,omitempty"`
+	Lab         string `protobuf:"bytes,6,opt,name=lab,proto3" json:"lab,omitempty"`
+	Line        string `protobuf:"bytes,7,opt,name=line,proto3" json:"line,omitempty"`
+	Notes       string `protobuf:"bytes,8,opt,name=notes,proto3" json:"notes,omitempty"`
+	Responsible string `protobuf:"bytes,9,opt,name=responsible,proto3" json:"responsible,omitempty"`
+}
+
+func (x *CreateTankRequest) Reset() {
+	*x = CreateTankRequest{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_tank_proto_msgTypes[17]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *CreateTankRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*CreateTankRequest) ProtoMessage() {}
+
+func (x *CreateTankRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_tank_proto_msgTypes[17]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use CreateTankRequest.ProtoReflect.Descriptor instead.
+func (*CreateTankRequest) Descriptor() ([]byte, []int) {
+	return file_tank_proto_rawDescGZIP(), []int{17}
+}
+
+func (x *CreateTankRequest) GetSystem() string {
+	if x != nil {
+		return x.System
+	}
+	return ""
+}
+
+func (x *CreateTankRequest) GetNumber() uint32 {
+	if x != nil {
+		return x.Number
+	}
+	return 0
+}
+
+func (x *CreateTankRequest) GetActive() bool {
+	if x != nil {
+		return x.Active
+	}
+	return false
+}
+
+func (x *CreateTankRequest) GetSize() uint32 {
 	if x != nil {
 		return x.Size
 	}
@@ -470,6 +1459,34 @@ func (x *CreateTankRequest) GetFishCount() uint32 {
 	return 0
 }
 
+func (x *CreateTankRequest) GetLab() string {
+	if x != nil {
+		return x.Lab
+	}
+	return ""
+}
+
+func (x *CreateTankRequest) GetLine() string {
+	if x != nil {
+		return x.Line
+	}
+	return ""
+}
+
+func (x *CreateTankRequest) GetNotes() string {
+	if x != nil {
+		return x.Notes
+	}
+	return ""
+}
+
+func (x *CreateTankRequest) GetResponsible() string {
+	if x != nil {
+		return x.Responsible
+	}
+	return ""
+}
+
 type CreateTankResponse struct {
 	state         protoimpl.MessageState
 	sizeCache     protoimpl.SizeCache
@@ -479,7 +1496,7 @@ type CreateTankResponse struct {
 func (x *CreateTankResponse) Reset() {
 	*x = CreateTankResponse{}
 	if protoimpl.UnsafeEnabled {
-		mi := &file_tank_proto_msgTypes[7]
+		mi := &file_tank_proto_msgTypes[18]
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		ms.StoreMessageInfo(mi)
 	}
@@ -492,7 +1509,7 @@ func (x *CreateTankResponse) String() string {
 func (*CreateTankResponse) ProtoMessage() {}
 
 func (x *CreateTankResponse) ProtoReflect() protoreflect.Message {
-	mi := &file_tank_proto_msgTypes[7]
+	mi := &file_tank_proto_msgTypes[18]
 	if protoimpl.UnsafeEnabled && x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -505,7 +1522,7 @@ func (x *CreateTankResponse) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use CreateTankResponse.ProtoReflect.Descriptor instead.
 func (*CreateTankResponse) Descriptor() ([]byte, []int) {
-	return file_tank_proto_rawDescGZIP(), []int{7}
+	return file_tank_proto_rawDescGZIP(), []int{18}
 }
 
 type UpdateTankRequest struct {
@@ -521,7 +1538,7 @@ type UpdateTankRequest struct {
 func (x *UpdateTankRequest) Reset() {
 	*x = UpdateTankRequest{}
 	if protoimpl.UnsafeEnabled {
-		mi := &file_tank_proto_msgTypes[8]
+		mi := &file_tank_proto_msgTypes[19]
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		ms.StoreMessageInfo(mi)
 	}
@@ -534,7 +1551,7 @@ func (x *UpdateTankRequest) String() string {
 func (*UpdateTankRequest) ProtoMessage() {}
 
 func (x *UpdateTankRequest) ProtoReflect() protoreflect.Message {
-	mi := &file_tank_proto_msgTypes[8]
+	mi := &file_tank_proto_msgTypes[19]
 	if protoimpl.UnsafeEnabled && x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -547,7 +1564,7 @@ func (x *UpdateTankRequest) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use UpdateTankRequest.ProtoReflect.Descriptor instead.
 func (*UpdateTankRequest) Descriptor() ([]byte, []int) {
-	return file_tank_proto_rawDescGZIP(), []int{8}
+	return file_tank_proto_rawDescGZIP(), []int{19}
 }
 
 func (x *UpdateTankRequest) GetNumber() uint32 {
@@ -559,41 +1576,260 @@ func (x *UpdateTankRequest) GetNumber() uint32 {
 
 func (x *UpdateTankRequest) GetTank() *Tank {
 	if x != nil {
-		return x.Tank
+		return x.Tank
+	}
+	return nil
+}
+
+func (x *UpdateTankRequest) GetMask() *fieldmaskpb.FieldMask {
+	if x != nil {
+		return x.Mask
+	}
+	return nil
+}
+
+type UpdateTankResponse struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+}
+
+func (x *UpdateTankResponse) Reset() {
+	*x = UpdateTankResponse{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_tank_proto_msgTypes[20]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *UpdateTankResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*UpdateTankResponse) ProtoMessage() {}
+
+func (x *UpdateTankResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_tank_proto_msgTypes[20]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use UpdateTankResponse.ProtoReflect.Descriptor instead.
+func (*UpdateTankResponse) Descriptor() ([]byte, []int) {
+	return file_tank_proto_rawDescGZIP(), []int{20}
+}
+
+type DeleteTankRequest struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	Number uint32 `protobuf:"varint,1,opt,name=number,proto3" json:"number,omitempty"`
+}
+
+func (x *DeleteTankRequest) Reset() {
+	*x = DeleteTankRequest{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_tank_proto_msgTypes[21]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *DeleteTankRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*DeleteTankRequest) ProtoMessage() {}
+
+func (x *DeleteTankRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_tank_proto_msgTypes[21]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use DeleteTankRequest.ProtoReflect.Descriptor instead.
+func (*DeleteTankRequest) Descriptor() ([]byte, []int) {
+	return file_tank_proto_rawDescGZIP(), []int{21}
+}
+
+func (x *DeleteTankRequest) GetNumber() uint32 {
+	if x != nil {
+		return x.Number
+	}
+	return 0
+}
+
+type DeleteTankResponse struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+}
+
+func (x *DeleteTankResponse) Reset() {
+	*x = DeleteTankResponse{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_tank_proto_msgTypes[22]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *DeleteTankResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*DeleteTankResponse) ProtoMessage() {}
+
+func (x *DeleteTankResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_tank_proto_msgTypes[22]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use DeleteTankResponse.ProtoReflect.Descriptor instead.
+func (*DeleteTankResponse) Descriptor() ([]byte, []int) {
+	return file_tank_proto_rawDescGZIP(), []int{22}
+}
+
+type GenerateTankLabelsRequest struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	// numbers selects the tanks to label. If it is empty, all tanks matching
+	// filters and filter are labeled.
+	Numbers   []uint32          `protobuf:"varint,1,rep,packed,name=numbers,proto3" json:"numbers,omitempty"`
+	Filters   []*proto.Filter   `protobuf:"bytes,2,rep,name=filters,proto3" json:"filters,omitempty"`
+	Filter    *FilterExpression `protobuf:"bytes,3,opt,name=filter,proto3" json:"filter,omitempty"`
+	Format    LabelFormat       `protobuf:"varint,4,opt,name=format,proto3,enum=anchamber.genetics.LabelFormat" json:"format,omitempty"`
+	Symbology Symbology         `protobuf:"varint,5,opt,name=symbology,proto3,enum=anchamber.genetics.Symbology" json:"symbology,omitempty"`
+	// template names the layout of the labels. It defaults to "a4-3x8" for SVG
+	// and PNG and to "thermal-50x25" for ZPL.
+	Template string `protobuf:"bytes,6,opt,name=template,proto3" json:"template,omitempty"`
+}
+
+func (x *GenerateTankLabelsRequest) Reset() {
+	*x = GenerateTankLabelsRequest{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_tank_proto_msgTypes[23]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *GenerateTankLabelsRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*GenerateTankLabelsRequest) ProtoMessage() {}
+
+func (x *GenerateTankLabelsRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_tank_proto_msgTypes[23]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use GenerateTankLabelsRequest.ProtoReflect.Descriptor instead.
+func (*GenerateTankLabelsRequest) Descriptor() ([]byte, []int) {
+	return file_tank_proto_rawDescGZIP(), []int{23}
+}
+
+func (x *GenerateTankLabelsRequest) GetNumbers() []uint32 {
+	if x != nil {
+		return x.Numbers
+	}
+	return nil
+}
+
+func (x *GenerateTankLabelsRequest) GetFilters() []*proto.Filter {
+	if x != nil {
+		return x.Filters
+	}
+	return nil
+}
+
+func (x *GenerateTankLabelsRequest) GetFilter() *FilterExpression {
+	if x != nil {
+		return x.Filter
+	}
+	return nil
+}
+
+func (x *GenerateTankLabelsRequest) GetFormat() LabelFormat {
+	if x != nil {
+		return x.Format
+	}
+	return LabelFormat_SVG
+}
+
+func (x *GenerateTankLabelsRequest) GetSymbology() Symbology {
+	if x != nil {
+		return x.Symbology
 	}
-	return nil
+	return Symbology_CODE128
 }
 
-func (x *UpdateTankRequest) GetMask() *fieldmaskpb.FieldMask {
+func (x *GenerateTankLabelsRequest) GetTemplate() string {
 	if x != nil {
-		return x.Mask
+		return x.Template
 	}
-	return nil
+	return ""
 }
 
-type UpdateTankResponse struct {
+type GenerateTankLabelsResponse struct {
 	state         protoimpl.MessageState
 	sizeCache     protoimpl.SizeCache
 	unknownFields protoimpl.UnknownFields
+
+	ContentType string `protobuf:"bytes,1,opt,name=contentType,proto3" json:"contentType,omitempty"`
+	// pages are the rendered sheets. ZPL output is a single page containing
+	// all labels.
+	Pages [][]byte `protobuf:"bytes,2,rep,name=pages,proto3" json:"pages,omitempty"`
 }
 
-func (x *UpdateTankResponse) Reset() {
-	*x = UpdateTankResponse{}
+func (x *GenerateTankLabelsResponse) Reset() {
+	*x = GenerateTankLabelsResponse{}
 	if protoimpl.UnsafeEnabled {
-		mi := &file_tank_proto_msgTypes[9]
+		mi := &file_tank_proto_msgTypes[24]
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		ms.StoreMessageInfo(mi)
 	}
 }
 
-func (x *UpdateTankResponse) String() string {
+func (x *GenerateTankLabelsResponse) String() string {
 	return protoimpl.X.MessageStringOf(x)
 }
 
-func (*UpdateTankResponse) ProtoMessage() {}
+func (*GenerateTankLabelsResponse) ProtoMessage() {}
 
-func (x *UpdateTankResponse) ProtoReflect() protoreflect.Message {
-	mi := &file_tank_proto_msgTypes[9]
+func (x *GenerateTankLabelsResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_tank_proto_msgTypes[24]
 	if protoimpl.UnsafeEnabled && x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -604,36 +1840,54 @@ func (x *UpdateTankResponse) ProtoReflect() protoreflect.Message {
 	return mi.MessageOf(x)
 }
 
-// Deprecated: Use UpdateTankResponse.ProtoReflect.Descriptor instead.
-func (*UpdateTankResponse) Descriptor() ([]byte, []int) {
-	return file_tank_proto_rawDescGZIP(), []int{9}
+// Deprecated: Use GenerateTankLabelsResponse.ProtoReflect.Descriptor instead.
+func (*GenerateTankLabelsResponse) Descriptor() ([]byte, []int) {
+	return file_tank_proto_rawDescGZIP(), []int{24}
 }
 
-type DeleteTankRequest struct {
+func (x *GenerateTankLabelsResponse) GetContentType() string {
+	if x != nil {
+		return x.ContentType
+	}
+	return ""
+}
+
+func (x *GenerateTankLabelsResponse) GetPages() [][]byte {
+	if x != nil {
+		return x.Pages
+	}
+	return nil
+}
+
+type ResolveScanRequest struct {
 	state         protoimpl.MessageState
 	sizeCache     protoimpl.SizeCache
 	unknownFields protoimpl.UnknownFields
 
-	Number uint32 `protobuf:"varint,1,opt,name=number,proto3" json:"number,omitempty"`
+	// payload is the content of the scanned code as sent by the scanner.
+	Payload string `protobuf:"bytes,1,opt,name=payload,proto3" json:"payload,omitempty"`
+	// readMask names the fields of TankResponse to return. All fields are
+	// returned if it is empty.
+	ReadMask *fieldmaskpb.FieldMask `protobuf:"bytes,2,opt,name=readMask,proto3" json:"readMask,omitempty"`
 }
 
-func (x *DeleteTankRequest) Reset() {
-	*x = DeleteTankRequest{}
+func (x *ResolveScanRequest) Reset() {
+	*x = ResolveScanRequest{}
 	if protoimpl.UnsafeEnabled {
-		mi := &file_tank_proto_msgTypes[10]
+		mi := &file_tank_proto_msgTypes[25]
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		ms.StoreMessageInfo(mi)
 	}
 }
 
-func (x *DeleteTankRequest) String() string {
+func (x *ResolveScanRequest) String() string {
 	return protoimpl.X.MessageStringOf(x)
 }
 
-func (*DeleteTankRequest) ProtoMessage() {}
+func (*ResolveScanRequest) ProtoMessage() {}
 
-func (x *DeleteTankRequest) ProtoReflect() protoreflect.Message {
-	mi := &file_tank_proto_msgTypes[10]
+func (x *ResolveScanRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_tank_proto_msgTypes[25]
 	if protoimpl.UnsafeEnabled && x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -644,41 +1898,53 @@ func (x *DeleteTankRequest) ProtoReflect() protoreflect.Message {
 	return mi.MessageOf(x)
 }
 
-// Deprecated: Use DeleteTankRequest.ProtoReflect.Descriptor instead.
-func (*DeleteTankRequest) Descriptor() ([]byte, []int) {
-	return file_tank_proto_rawDescGZIP(), []int{10}
+// Deprecated: Use ResolveScanRequest.ProtoReflect.Descriptor instead.
+func (*ResolveScanRequest) Descriptor() ([]byte, []int) {
+	return file_tank_proto_rawDescGZIP(), []int{25}
 }
 
-func (x *DeleteTankRequest) GetNumber() uint32 {
+func (x *ResolveScanRequest) GetPayload() string {
 	if x != nil {
-		return x.Number
+		return x.Payload
 	}
-	return 0
+	return ""
 }
 
-type DeleteTankResponse struct {
+func (x *ResolveScanRequest) GetReadMask() *fieldmaskpb.FieldMask {
+	if x != nil {
+		return x.ReadMask
+	}
+	return nil
+}
+
+type ScanActionRequest struct {
 	state         protoimpl.MessageState
 	sizeCache     protoimpl.SizeCache
 	unknownFields protoimpl.UnknownFields
+
+	Payload string     `protobuf:"bytes,1,opt,name=payload,proto3" json:"payload,omitempty"`
+	Action  TankAction `protobuf:"varint,2,opt,name=action,proto3,enum=anchamber.genetics.TankAction" json:"action,omitempty"`
+	// count is the number of dead fish for MORTALITY.
+	Count uint32 `protobuf:"varint,3,opt,name=count,proto3" json:"count,omitempty"`
 }
 
-func (x *DeleteTankResponse) Reset() {
-	*x = DeleteTankResponse{}
+func (x *ScanActionRequest) Reset() {
+	*x = ScanActionRequest{}
 	if protoimpl.UnsafeEnabled {
-		mi := &file_tank_proto_msgTypes[11]
+		mi := &file_tank_proto_msgTypes[26]
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		ms.StoreMessageInfo(mi)
 	}
 }
 
-func (x *DeleteTankResponse) String() string {
+func (x *ScanActionRequest) String() string {
 	return protoimpl.X.MessageStringOf(x)
 }
 
-func (*DeleteTankResponse) ProtoMessage() {}
+func (*ScanActionRequest) ProtoMessage() {}
 
-func (x *DeleteTankResponse) ProtoReflect() protoreflect.Message {
-	mi := &file_tank_proto_msgTypes[11]
+func (x *ScanActionRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_tank_proto_msgTypes[26]
 	if protoimpl.UnsafeEnabled && x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -689,9 +1955,30 @@ func (x *DeleteTankResponse) ProtoReflect() protoreflect.Message {
 	return mi.MessageOf(x)
 }
 
-// Deprecated: Use DeleteTankResponse.ProtoReflect.Descriptor instead.
-func (*DeleteTankResponse) Descriptor() ([]byte, []int) {
-	return file_tank_proto_rawDescGZIP(), []int{11}
+// Deprecated: Use ScanActionRequest.ProtoReflect.Descriptor instead.
+func (*ScanActionRequest) Descriptor() ([]byte, []int) {
+	return file_tank_proto_rawDescGZIP(), []int{26}
+}
+
+func (x *ScanActionRequest) GetPayload() string {
+	if x != nil {
+		return x.Payload
+	}
+	return ""
+}
+
+func (x *ScanActionRequest) GetAction() TankAction {
+	if x != nil {
+		return x.Action
+	}
+	return TankAction_TANK_ACTION_UNSPECIFIED
+}
+
+func (x *ScanActionRequest) GetCount() uint32 {
+	if x != nil {
+		return x.Count
+	}
+	return 0
 }
 
 var File_tank_proto protoreflect.FileDescriptor
@@ -701,7 +1988,7 @@ var file_tank_proto_rawDesc = []byte{
 	0x63, 0x68, 0x61, 0x6d, 0x62, 0x65, 0x72, 0x2e, 0x67, 0x65, 0x6e, 0x65, 0x74, 0x69, 0x63, 0x73,
 	0x1a, 0x09, 0x61, 0x70, 0x69, 0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x1a, 0x20, 0x67, 0x6f, 0x6f,
 	0x67, 0x6c, 0x65, 0x2f, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x62, 0x75, 0x66, 0x2f, 0x66, 0x69, 0x65,
-	0x6c, 0x64, 0x5f, 0x6d, 0x61, 0x73, 0x6b, 0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x22, 0x80, 0x01,
+	0x6c, 0x64, 0x5f, 0x6d, 0x61, 0x73, 0x6b, 0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x22, 0xde, 0x01,
 	0x0a, 0x04, 0x54, 0x61, 0x6e, 0x6b, 0x12, 0x16, 0x0a, 0x06, 0x73, 0x79, 0x73, 0x74, 0x65, 0x6d,
 	0x18, 0x01, 0x20, 0x01, 0x28, 0x09, 0x52, 0x06, 0x73, 0x79, 0x73, 0x74, 0x65, 0x6d, 0x12, 0x16,
 	0x0a, 0x06, 0x6e, 0x75, 0x6d, 0x62, 0x65, 0x72, 0x18, 0x02, 0x20, 0x01, 0x28, 0x0d, 0x52, 0x06,
@@ -710,28 +1997,138 @@ var file_tank_proto_rawDesc = []byte{
 	0x0a, 0x04, 0x73, 0x69, 0x7a, 0x65, 0x18, 0x04, 0x20, 0x01, 0x28, 0x0d, 0x52, 0x04, 0x73, 0x69,
 	0x7a, 0x65, 0x12, 0x1c, 0x0a, 0x09, 0x66, 0x69, 0x73, 0x68, 0x43, 0x6f, 0x75, 0x6e, 0x74, 0x18,
 	0x05, 0x20, 0x01, 0x28, 0x0d, 0x52, 0x09, 0x66, 0x69, 0x73, 0x68, 0x43, 0x6f, 0x75, 0x6e, 0x74,
-	0x22, 0x94, 0x01, 0x0a, 0x12, 0x53, 0x74, 0x72, 0x65, 0x61, 0x6d, 0x54, 0x61, 0x6e, 0x6b, 0x73,
-	0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x12, 0x38, 0x0a, 0x07, 0x66, 0x69, 0x6c, 0x74, 0x65,
-	0x72, 0x73, 0x18, 0x01, 0x20, 0x03, 0x28, 0x0b, 0x32, 0x1e, 0x2e, 0x61, 0x6e, 0x63, 0x68, 0x61,
-	0x6d, 0x62, 0x65, 0x72, 0x2e, 0x67, 0x65, 0x6e, 0x65, 0x74, 0x69, 0x63, 0x73, 0x2e, 0x61, 0x70,
-	0x69, 0x2e, 0x46, 0x69, 0x6c, 0x74, 0x65, 0x72, 0x52, 0x07, 0x66, 0x69, 0x6c, 0x74, 0x65, 0x72,
-	0x73, 0x12, 0x44, 0x0a, 0x0b, 0x70, 0x61, 0x67, 0x65, 0x69, 0x6e, 0x61, 0x74, 0x69, 0x6f, 0x6e,
-	0x18, 0x02, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x22, 0x2e, 0x61, 0x6e, 0x63, 0x68, 0x61, 0x6d, 0x62,
+	0x12, 0x10, 0x0a, 0x03, 0x6c, 0x61, 0x62, 0x18, 0x06, 0x20, 0x01, 0x28, 0x09, 0x52, 0x03, 0x6c,
+	0x61, 0x62, 0x12, 0x12, 0x0a, 0x04, 0x6c, 0x69, 0x6e, 0x65, 0x18, 0x07, 0x20, 0x01, 0x28, 0x09,
+	0x52, 0x04, 0x6c, 0x69, 0x6e, 0x65, 0x12, 0x14, 0x0a, 0x05, 0x6e, 0x6f, 0x74, 0x65, 0x73, 0x18,
+	0x08, 0x20, 0x01, 0x28, 0x09, 0x52, 0x05, 0x6e, 0x6f, 0x74, 0x65, 0x73, 0x12, 0x20, 0x0a, 0x0b,
+	0x72, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x69, 0x62, 0x6c, 0x65, 0x18, 0x09, 0x20, 0x01, 0x28,
+	0x09, 0x52, 0x0b, 0x72, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x69, 0x62, 0x6c, 0x65, 0x22, 0xfb,
+	0x02, 0x0a, 0x12, 0x53, 0x74, 0x72, 0x65, 0x61, 0x6d, 0x54, 0x61, 0x6e, 0x6b, 0x73, 0x52, 0x65,
+	0x71, 0x75, 0x65, 0x73, 0x74, 0x12, 0x38, 0x0a, 0x07, 0x66, 0x69, 0x6c, 0x74, 0x65, 0x72, 0x73,
+	0x18, 0x01, 0x20, 0x03, 0x28, 0x0b, 0x32, 0x1e, 0x2e, 0x61, 0x6e, 0x63, 0x68, 0x61, 0x6d, 0x62,
 	0x65, 0x72, 0x2e, 0x67, 0x65, 0x6e, 0x65, 0x74, 0x69, 0x63, 0x73, 0x2e, 0x61, 0x70, 0x69, 0x2e,
-	0x50, 0x61, 0x67, 0x69, 0x6e, 0x61, 0x74, 0x69, 0x6f, 0x6e, 0x52, 0x0b, 0x70, 0x61, 0x67, 0x65,
-	0x69, 0x6e, 0x61, 0x74, 0x69, 0x6f, 0x6e, 0x22, 0x28, 0x0a, 0x0e, 0x47, 0x65, 0x74, 0x54, 0x61,
-	0x6e, 0x6b, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x12, 0x16, 0x0a, 0x06, 0x6e, 0x75, 0x6d,
-	0x62, 0x65, 0x72, 0x18, 0x01, 0x20, 0x01, 0x28, 0x0d, 0x52, 0x06, 0x6e, 0x75, 0x6d, 0x62, 0x65,
-	0x72, 0x22, 0x98, 0x01, 0x0a, 0x0c, 0x54, 0x61, 0x6e, 0x6b, 0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e,
-	0x73, 0x65, 0x12, 0x0e, 0x0a, 0x02, 0x69, 0x64, 0x18, 0x01, 0x20, 0x01, 0x28, 0x03, 0x52, 0x02,
-	0x69, 0x64, 0x12, 0x16, 0x0a, 0x06, 0x73, 0x79, 0x73, 0x74, 0x65, 0x6d, 0x18, 0x02, 0x20, 0x01,
-	0x28, 0x09, 0x52, 0x06, 0x73, 0x79, 0x73, 0x74, 0x65, 0x6d, 0x12, 0x16, 0x0a, 0x06, 0x6e, 0x75,
-	0x6d, 0x62, 0x65, 0x72, 0x18, 0x03, 0x20, 0x01, 0x28, 0x0d, 0x52, 0x06, 0x6e, 0x75, 0x6d, 0x62,
-	0x65, 0x72, 0x12, 0x16, 0x0a, 0x06, 0x61, 0x63, 0x74, 0x69, 0x76, 0x65, 0x18, 0x04, 0x20, 0x01,
-	0x28, 0x08, 0x52, 0x06, 0x61, 0x63, 0x74, 0x69, 0x76, 0x65, 0x12, 0x12, 0x0a, 0x04, 0x73, 0x69,
-	0x7a, 0x65, 0x18, 0x05, 0x20, 0x01, 0x28, 0x0d, 0x52, 0x04, 0x73, 0x69, 0x7a, 0x65, 0x12, 0x1c,
-	0x0a, 0x09, 0x66, 0x69, 0x73, 0x68, 0x43, 0x6f, 0x75, 0x6e, 0x74, 0x18, 0x06, 0x20, 0x01, 0x28,
-	0x0d, 0x52, 0x09, 0x66, 0x69, 0x73, 0x68, 0x43, 0x6f, 0x75, 0x6e, 0x74, 0x22, 0x15, 0x0a, 0x13,
+	0x46, 0x69, 0x6c, 0x74, 0x65, 0x72, 0x52, 0x07, 0x66, 0x69, 0x6c, 0x74, 0x65, 0x72, 0x73, 0x12,
+	0x44, 0x0a, 0x0b, 0x70, 0x61, 0x67, 0x65, 0x69, 0x6e, 0x61, 0x74, 0x69, 0x6f, 0x6e, 0x18, 0x02,
+	0x20, 0x01, 0x28, 0x0b, 0x32, 0x22, 0x2e, 0x61, 0x6e, 0x63, 0x68, 0x61, 0x6d, 0x62, 0x65, 0x72,
+	0x2e, 0x67, 0x65, 0x6e, 0x65, 0x74, 0x69, 0x63, 0x73, 0x2e, 0x61, 0x70, 0x69, 0x2e, 0x50, 0x61,
+	0x67, 0x69, 0x6e, 0x61, 0x74, 0x69, 0x6f, 0x6e, 0x52, 0x0b, 0x70, 0x61, 0x67, 0x65, 0x69, 0x6e,
+	0x61, 0x74, 0x69, 0x6f, 0x6e, 0x12, 0x3c, 0x0a, 0x06, 0x66, 0x69, 0x6c, 0x74, 0x65, 0x72, 0x18,
+	0x03, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x24, 0x2e, 0x61, 0x6e, 0x63, 0x68, 0x61, 0x6d, 0x62, 0x65,
+	0x72, 0x2e, 0x67, 0x65, 0x6e, 0x65, 0x74, 0x69, 0x63, 0x73, 0x2e, 0x46, 0x69, 0x6c, 0x74, 0x65,
+	0x72, 0x45, 0x78, 0x70, 0x72, 0x65, 0x73, 0x73, 0x69, 0x6f, 0x6e, 0x52, 0x06, 0x66, 0x69, 0x6c,
+	0x74, 0x65, 0x72, 0x12, 0x35, 0x0a, 0x07, 0x6f, 0x72, 0x64, 0x65, 0x72, 0x42, 0x79, 0x18, 0x04,
+	0x20, 0x03, 0x28, 0x0b, 0x32, 0x1b, 0x2e, 0x61, 0x6e, 0x63, 0x68, 0x61, 0x6d, 0x62, 0x65, 0x72,
+	0x2e, 0x67, 0x65, 0x6e, 0x65, 0x74, 0x69, 0x63, 0x73, 0x2e, 0x4f, 0x72, 0x64, 0x65, 0x72, 0x42,
+	0x79, 0x52, 0x07, 0x6f, 0x72, 0x64, 0x65, 0x72, 0x42, 0x79, 0x12, 0x1c, 0x0a, 0x09, 0x70, 0x61,
+	0x67, 0x65, 0x54, 0x6f, 0x6b, 0x65, 0x6e, 0x18, 0x05, 0x20, 0x01, 0x28, 0x09, 0x52, 0x09, 0x70,
+	0x61, 0x67, 0x65, 0x54, 0x6f, 0x6b, 0x65, 0x6e, 0x12, 0x1a, 0x0a, 0x08, 0x70, 0x61, 0x67, 0x65,
+	0x53, 0x69, 0x7a, 0x65, 0x18, 0x06, 0x20, 0x01, 0x28, 0x0d, 0x52, 0x08, 0x70, 0x61, 0x67, 0x65,
+	0x53, 0x69, 0x7a, 0x65, 0x12, 0x36, 0x0a, 0x08, 0x72, 0x65, 0x61, 0x64, 0x4d, 0x61, 0x73, 0x6b,
+	0x18, 0x07, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x1a, 0x2e, 0x67, 0x6f, 0x6f, 0x67, 0x6c, 0x65, 0x2e,
+	0x70, 0x72, 0x6f, 0x74, 0x6f, 0x62, 0x75, 0x66, 0x2e, 0x46, 0x69, 0x65, 0x6c, 0x64, 0x4d, 0x61,
+	0x73, 0x6b, 0x52, 0x08, 0x72, 0x65, 0x61, 0x64, 0x4d, 0x61, 0x73, 0x6b, 0x22, 0x8b, 0x01, 0x0a,
+	0x11, 0x43, 0x6f, 0x75, 0x6e, 0x74, 0x54, 0x61, 0x6e, 0x6b, 0x73, 0x52, 0x65, 0x71, 0x75, 0x65,
+	0x73, 0x74, 0x12, 0x38, 0x0a, 0x07, 0x66, 0x69, 0x6c, 0x74, 0x65, 0x72, 0x73, 0x18, 0x01, 0x20,
+	0x03, 0x28, 0x0b, 0x32, 0x1e, 0x2e, 0x61, 0x6e, 0x63, 0x68, 0x61, 0x6d, 0x62, 0x65, 0x72, 0x2e,
+	0x67, 0x65, 0x6e, 0x65, 0x74, 0x69, 0x63, 0x73, 0x2e, 0x61, 0x70, 0x69, 0x2e, 0x46, 0x69, 0x6c,
+	0x74, 0x65, 0x72, 0x52, 0x07, 0x66, 0x69, 0x6c, 0x74, 0x65, 0x72, 0x73, 0x12, 0x3c, 0x0a, 0x06,
+	0x66, 0x69, 0x6c, 0x74, 0x65, 0x72, 0x18, 0x02, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x24, 0x2e, 0x61,
+	0x6e, 0x63, 0x68, 0x61, 0x6d, 0x62, 0x65, 0x72, 0x2e, 0x67, 0x65, 0x6e, 0x65, 0x74, 0x69, 0x63,
+	0x73, 0x2e, 0x46, 0x69, 0x6c, 0x74, 0x65, 0x72, 0x45, 0x78, 0x70, 0x72, 0x65, 0x73, 0x73, 0x69,
+	0x6f, 0x6e, 0x52, 0x06, 0x66, 0x69, 0x6c, 0x74, 0x65, 0x72, 0x22, 0x2a, 0x0a, 0x12, 0x43, 0x6f,
+	0x75, 0x6e, 0x74, 0x54, 0x61, 0x6e, 0x6b, 0x73, 0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65,
+	0x12, 0x14, 0x0a, 0x05, 0x63, 0x6f, 0x75, 0x6e, 0x74, 0x18, 0x01, 0x20, 0x01, 0x28, 0x04, 0x52,
+	0x05, 0x63, 0x6f, 0x75, 0x6e, 0x74, 0x22, 0x40, 0x0a, 0x12, 0x53, 0x65, 0x61, 0x72, 0x63, 0x68,
+	0x54, 0x61, 0x6e, 0x6b, 0x73, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x12, 0x14, 0x0a, 0x05,
+	0x71, 0x75, 0x65, 0x72, 0x79, 0x18, 0x01, 0x20, 0x01, 0x28, 0x09, 0x52, 0x05, 0x71, 0x75, 0x65,
+	0x72, 0x79, 0x12, 0x14, 0x0a, 0x05, 0x6c, 0x69, 0x6d, 0x69, 0x74, 0x18, 0x02, 0x20, 0x01, 0x28,
+	0x0d, 0x52, 0x05, 0x6c, 0x69, 0x6d, 0x69, 0x74, 0x22, 0x51, 0x0a, 0x13, 0x53, 0x65, 0x61, 0x72,
+	0x63, 0x68, 0x54, 0x61, 0x6e, 0x6b, 0x73, 0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x12,
+	0x3a, 0x0a, 0x07, 0x72, 0x65, 0x73, 0x75, 0x6c, 0x74, 0x73, 0x18, 0x01, 0x20, 0x03, 0x28, 0x0b,
+	0x32, 0x20, 0x2e, 0x61, 0x6e, 0x63, 0x68, 0x61, 0x6d, 0x62, 0x65, 0x72, 0x2e, 0x67, 0x65, 0x6e,
+	0x65, 0x74, 0x69, 0x63, 0x73, 0x2e, 0x53, 0x65, 0x61, 0x72, 0x63, 0x68, 0x52, 0x65, 0x73, 0x75,
+	0x6c, 0x74, 0x52, 0x07, 0x72, 0x65, 0x73, 0x75, 0x6c, 0x74, 0x73, 0x22, 0x72, 0x0a, 0x0c, 0x53,
+	0x65, 0x61, 0x72, 0x63, 0x68, 0x52, 0x65, 0x73, 0x75, 0x6c, 0x74, 0x12, 0x34, 0x0a, 0x04, 0x74,
+	0x61, 0x6e, 0x6b, 0x18, 0x01, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x20, 0x2e, 0x61, 0x6e, 0x63, 0x68,
+	0x61, 0x6d, 0x62, 0x65, 0x72, 0x2e, 0x67, 0x65, 0x6e, 0x65, 0x74, 0x69, 0x63, 0x73, 0x2e, 0x54,
+	0x61, 0x6e, 0x6b, 0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x52, 0x04, 0x74, 0x61, 0x6e,
+	0x6b, 0x12, 0x12, 0x0a, 0x04, 0x72, 0x61, 0x6e, 0x6b, 0x18, 0x02, 0x20, 0x01, 0x28, 0x01, 0x52,
+	0x04, 0x72, 0x61, 0x6e, 0x6b, 0x12, 0x18, 0x0a, 0x07, 0x73, 0x6e, 0x69, 0x70, 0x70, 0x65, 0x74,
+	0x18, 0x03, 0x20, 0x01, 0x28, 0x09, 0x52, 0x07, 0x73, 0x6e, 0x69, 0x70, 0x70, 0x65, 0x74, 0x22,
+	0x3b, 0x0a, 0x07, 0x4f, 0x72, 0x64, 0x65, 0x72, 0x42, 0x79, 0x12, 0x10, 0x0a, 0x03, 0x6b, 0x65,
+	0x79, 0x18, 0x01, 0x20, 0x01, 0x28, 0x09, 0x52, 0x03, 0x6b, 0x65, 0x79, 0x12, 0x1e, 0x0a, 0x0a,
+	0x64, 0x65, 0x73, 0x63, 0x65, 0x6e, 0x64, 0x69, 0x6e, 0x67, 0x18, 0x02, 0x20, 0x01, 0x28, 0x08,
+	0x52, 0x0a, 0x64, 0x65, 0x73, 0x63, 0x65, 0x6e, 0x64, 0x69, 0x6e, 0x67, 0x22, 0xbf, 0x03, 0x0a,
+	0x10, 0x46, 0x69, 0x6c, 0x74, 0x65, 0x72, 0x45, 0x78, 0x70, 0x72, 0x65, 0x73, 0x73, 0x69, 0x6f,
+	0x6e, 0x12, 0x3c, 0x0a, 0x03, 0x61, 0x6e, 0x64, 0x18, 0x01, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x28,
+	0x2e, 0x61, 0x6e, 0x63, 0x68, 0x61, 0x6d, 0x62, 0x65, 0x72, 0x2e, 0x67, 0x65, 0x6e, 0x65, 0x74,
+	0x69, 0x63, 0x73, 0x2e, 0x46, 0x69, 0x6c, 0x74, 0x65, 0x72, 0x45, 0x78, 0x70, 0x72, 0x65, 0x73,
+	0x73, 0x69, 0x6f, 0x6e, 0x4c, 0x69, 0x73, 0x74, 0x48, 0x00, 0x52, 0x03, 0x61, 0x6e, 0x64, 0x12,
+	0x3a, 0x0a, 0x02, 0x6f, 0x72, 0x18, 0x02, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x28, 0x2e, 0x61, 0x6e,
+	0x63, 0x68, 0x61, 0x6d, 0x62, 0x65, 0x72, 0x2e, 0x67, 0x65, 0x6e, 0x65, 0x74, 0x69, 0x63, 0x73,
+	0x2e, 0x46, 0x69, 0x6c, 0x74, 0x65, 0x72, 0x45, 0x78, 0x70, 0x72, 0x65, 0x73, 0x73, 0x69, 0x6f,
+	0x6e, 0x4c, 0x69, 0x73, 0x74, 0x48, 0x00, 0x52, 0x02, 0x6f, 0x72, 0x12, 0x38, 0x0a, 0x03, 0x6e,
+	0x6f, 0x74, 0x18, 0x03, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x24, 0x2e, 0x61, 0x6e, 0x63, 0x68, 0x61,
+	0x6d, 0x62, 0x65, 0x72, 0x2e, 0x67, 0x65, 0x6e, 0x65, 0x74, 0x69, 0x63, 0x73, 0x2e, 0x46, 0x69,
+	0x6c, 0x74, 0x65, 0x72, 0x45, 0x78, 0x70, 0x72, 0x65, 0x73, 0x73, 0x69, 0x6f, 0x6e, 0x48, 0x00,
+	0x52, 0x03, 0x6e, 0x6f, 0x74, 0x12, 0x3e, 0x0a, 0x09, 0x63, 0x6f, 0x6e, 0x64, 0x69, 0x74, 0x69,
+	0x6f, 0x6e, 0x18, 0x04, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x1e, 0x2e, 0x61, 0x6e, 0x63, 0x68, 0x61,
+	0x6d, 0x62, 0x65, 0x72, 0x2e, 0x67, 0x65, 0x6e, 0x65, 0x74, 0x69, 0x63, 0x73, 0x2e, 0x61, 0x70,
+	0x69, 0x2e, 0x46, 0x69, 0x6c, 0x74, 0x65, 0x72, 0x48, 0x00, 0x52, 0x09, 0x63, 0x6f, 0x6e, 0x64,
+	0x69, 0x74, 0x69, 0x6f, 0x6e, 0x12, 0x2e, 0x0a, 0x02, 0x69, 0x6e, 0x18, 0x05, 0x20, 0x01, 0x28,
+	0x0b, 0x32, 0x1c, 0x2e, 0x61, 0x6e, 0x63, 0x68, 0x61, 0x6d, 0x62, 0x65, 0x72, 0x2e, 0x67, 0x65,
+	0x6e, 0x65, 0x74, 0x69, 0x63, 0x73, 0x2e, 0x49, 0x6e, 0x46, 0x69, 0x6c, 0x74, 0x65, 0x72, 0x48,
+	0x00, 0x52, 0x02, 0x69, 0x6e, 0x12, 0x3d, 0x0a, 0x07, 0x62, 0x65, 0x74, 0x77, 0x65, 0x65, 0x6e,
+	0x18, 0x06, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x21, 0x2e, 0x61, 0x6e, 0x63, 0x68, 0x61, 0x6d, 0x62,
+	0x65, 0x72, 0x2e, 0x67, 0x65, 0x6e, 0x65, 0x74, 0x69, 0x63, 0x73, 0x2e, 0x42, 0x65, 0x74, 0x77,
+	0x65, 0x65, 0x6e, 0x46, 0x69, 0x6c, 0x74, 0x65, 0x72, 0x48, 0x00, 0x52, 0x07, 0x62, 0x65, 0x74,
+	0x77, 0x65, 0x65, 0x6e, 0x12, 0x3a, 0x0a, 0x06, 0x69, 0x73, 0x4e, 0x75, 0x6c, 0x6c, 0x18, 0x07,
+	0x20, 0x01, 0x28, 0x0b, 0x32, 0x20, 0x2e, 0x61, 0x6e, 0x63, 0x68, 0x61, 0x6d, 0x62, 0x65, 0x72,
+	0x2e, 0x67, 0x65, 0x6e, 0x65, 0x74, 0x69, 0x63, 0x73, 0x2e, 0x49, 0x73, 0x4e, 0x75, 0x6c, 0x6c,
+	0x46, 0x69, 0x6c, 0x74, 0x65, 0x72, 0x48, 0x00, 0x52, 0x06, 0x69, 0x73, 0x4e, 0x75, 0x6c, 0x6c,
+	0x42, 0x0c, 0x0a, 0x0a, 0x65, 0x78, 0x70, 0x72, 0x65, 0x73, 0x73, 0x69, 0x6f, 0x6e, 0x22, 0x5e,
+	0x0a, 0x14, 0x46, 0x69, 0x6c, 0x74, 0x65, 0x72, 0x45, 0x78, 0x70, 0x72, 0x65, 0x73, 0x73, 0x69,
+	0x6f, 0x6e, 0x4c, 0x69, 0x73, 0x74, 0x12, 0x46, 0x0a, 0x0b, 0x65, 0x78, 0x70, 0x72, 0x65, 0x73,
+	0x73, 0x69, 0x6f, 0x6e, 0x73, 0x18, 0x01, 0x20, 0x03, 0x28, 0x0b, 0x32, 0x24, 0x2e, 0x61, 0x6e,
+	0x63, 0x68, 0x61, 0x6d, 0x62, 0x65, 0x72, 0x2e, 0x67, 0x65, 0x6e, 0x65, 0x74, 0x69, 0x63, 0x73,
+	0x2e, 0x46, 0x69, 0x6c, 0x74, 0x65, 0x72, 0x45, 0x78, 0x70, 0x72, 0x65, 0x73, 0x73, 0x69, 0x6f,
+	0x6e, 0x52, 0x0b, 0x65, 0x78, 0x70, 0x72, 0x65, 0x73, 0x73, 0x69, 0x6f, 0x6e, 0x73, 0x22, 0x34,
+	0x0a, 0x08, 0x49, 0x6e, 0x46, 0x69, 0x6c, 0x74, 0x65, 0x72, 0x12, 0x10, 0x0a, 0x03, 0x6b, 0x65,
+	0x79, 0x18, 0x01, 0x20, 0x01, 0x28, 0x09, 0x52, 0x03, 0x6b, 0x65, 0x79, 0x12, 0x16, 0x0a, 0x06,
+	0x76, 0x61, 0x6c, 0x75, 0x65, 0x73, 0x18, 0x02, 0x20, 0x03, 0x28, 0x09, 0x52, 0x06, 0x76, 0x61,
+	0x6c, 0x75, 0x65, 0x73, 0x22, 0x4d, 0x0a, 0x0d, 0x42, 0x65, 0x74, 0x77, 0x65, 0x65, 0x6e, 0x46,
+	0x69, 0x6c, 0x74, 0x65, 0x72, 0x12, 0x10, 0x0a, 0x03, 0x6b, 0x65, 0x79, 0x18, 0x01, 0x20, 0x01,
+	0x28, 0x09, 0x52, 0x03, 0x6b, 0x65, 0x79, 0x12, 0x14, 0x0a, 0x05, 0x6c, 0x6f, 0x77, 0x65, 0x72,
+	0x18, 0x02, 0x20, 0x01, 0x28, 0x09, 0x52, 0x05, 0x6c, 0x6f, 0x77, 0x65, 0x72, 0x12, 0x14, 0x0a,
+	0x05, 0x75, 0x70, 0x70, 0x65, 0x72, 0x18, 0x03, 0x20, 0x01, 0x28, 0x09, 0x52, 0x05, 0x75, 0x70,
+	0x70, 0x65, 0x72, 0x22, 0x20, 0x0a, 0x0c, 0x49, 0x73, 0x4e, 0x75, 0x6c, 0x6c, 0x46, 0x69, 0x6c,
+	0x74, 0x65, 0x72, 0x12, 0x10, 0x0a, 0x03, 0x6b, 0x65, 0x79, 0x18, 0x01, 0x20, 0x01, 0x28, 0x09,
+	0x52, 0x03, 0x6b, 0x65, 0x79, 0x22, 0x60, 0x0a, 0x0e, 0x47, 0x65, 0x74, 0x54, 0x61, 0x6e, 0x6b,
+	0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x12, 0x16, 0x0a, 0x06, 0x6e, 0x75, 0x6d, 0x62, 0x65,
+	0x72, 0x18, 0x01, 0x20, 0x01, 0x28, 0x0d, 0x52, 0x06, 0x6e, 0x75, 0x6d, 0x62, 0x65, 0x72, 0x12,
+	0x36, 0x0a, 0x08, 0x72, 0x65, 0x61, 0x64, 0x4d, 0x61, 0x73, 0x6b, 0x18, 0x02, 0x20, 0x01, 0x28,
+	0x0b, 0x32, 0x1a, 0x2e, 0x67, 0x6f, 0x6f, 0x67, 0x6c, 0x65, 0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f,
+	0x62, 0x75, 0x66, 0x2e, 0x46, 0x69, 0x65, 0x6c, 0x64, 0x4d, 0x61, 0x73, 0x6b, 0x52, 0x08, 0x72,
+	0x65, 0x61, 0x64, 0x4d, 0x61, 0x73, 0x6b, 0x22, 0xb2, 0x02, 0x0a, 0x0c, 0x54, 0x61, 0x6e, 0x6b,
+	0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x12, 0x0e, 0x0a, 0x02, 0x69, 0x64, 0x18, 0x01,
+	0x20, 0x01, 0x28, 0x03, 0x52, 0x02, 0x69, 0x64, 0x12, 0x16, 0x0a, 0x06, 0x73, 0x79, 0x73, 0x74,
+	0x65, 0x6d, 0x18, 0x02, 0x20, 0x01, 0x28, 0x09, 0x52, 0x06, 0x73, 0x79, 0x73, 0x74, 0x65, 0x6d,
+	0x12, 0x16, 0x0a, 0x06, 0x6e, 0x75, 0x6d, 0x62, 0x65, 0x72, 0x18, 0x03, 0x20, 0x01, 0x28, 0x0d,
+	0x52, 0x06, 0x6e, 0x75, 0x6d, 0x62, 0x65, 0x72, 0x12, 0x16, 0x0a, 0x06, 0x61, 0x63, 0x74, 0x69,
+	0x76, 0x65, 0x18, 0x04, 0x20, 0x01, 0x28, 0x08, 0x52, 0x06, 0x61, 0x63, 0x74, 0x69, 0x76, 0x65,
+	0x12, 0x12, 0x0a, 0x04, 0x73, 0x69, 0x7a, 0x65, 0x18, 0x05, 0x20, 0x01, 0x28, 0x0d, 0x52, 0x04,
+	0x73, 0x69, 0x7a, 0x65, 0x12, 0x1c, 0x0a, 0x09, 0x66, 0x69, 0x73, 0x68, 0x43, 0x6f, 0x75, 0x6e,
+	0x74, 0x18, 0x06, 0x20, 0x01, 0x28, 0x0d, 0x52, 0x09, 0x66, 0x69, 0x73, 0x68, 0x43, 0x6f, 0x75,
+	0x6e, 0x74, 0x12, 0x10, 0x0a, 0x03, 0x6c, 0x61, 0x62, 0x18, 0x07, 0x20, 0x01, 0x28, 0x09, 0x52,
+	0x03, 0x6c, 0x61, 0x62, 0x12, 0x12, 0x0a, 0x04, 0x6c, 0x69, 0x6e, 0x65, 0x18, 0x08, 0x20, 0x01,
+	0x28, 0x09, 0x52, 0x04, 0x6c, 0x69, 0x6e, 0x65, 0x12, 0x14, 0x0a, 0x05, 0x6e, 0x6f, 0x74, 0x65,
+	0x73, 0x18, 0x09, 0x20, 0x01, 0x28, 0x09, 0x52, 0x05, 0x6e, 0x6f, 0x74, 0x65, 0x73, 0x12, 0x20,
+	0x0a, 0x0b, 0x72, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x69, 0x62, 0x6c, 0x65, 0x18, 0x0a, 0x20,
+	0x01, 0x28, 0x09, 0x52, 0x0b, 0x72, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x69, 0x62, 0x6c, 0x65,
+	0x12, 0x20, 0x0a, 0x0b, 0x6c, 0x61, 0x73, 0x74, 0x43, 0x6c, 0x65, 0x61, 0x6e, 0x65, 0x64, 0x18,
+	0x0b, 0x20, 0x01, 0x28, 0x03, 0x52, 0x0b, 0x6c, 0x61, 0x73, 0x74, 0x43, 0x6c, 0x65, 0x61, 0x6e,
+	0x65, 0x64, 0x12, 0x18, 0x0a, 0x07, 0x6c, 0x61, 0x73, 0x74, 0x46, 0x65, 0x64, 0x18, 0x0c, 0x20,
+	0x01, 0x28, 0x03, 0x52, 0x07, 0x6c, 0x61, 0x73, 0x74, 0x46, 0x65, 0x64, 0x22, 0x15, 0x0a, 0x13,
 	0x47, 0x65, 0x74, 0x54, 0x61, 0x6e, 0x6b, 0x53, 0x74, 0x61, 0x74, 0x73, 0x52, 0x65, 0x71, 0x75,
 	0x65, 0x73, 0x74, 0x22, 0x9e, 0x01, 0x0a, 0x14, 0x47, 0x65, 0x74, 0x54, 0x61, 0x6e, 0x6b, 0x53,
 	0x74, 0x61, 0x74, 0x73, 0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x12, 0x22, 0x0a, 0x0c,
@@ -743,7 +2140,7 @@ var file_tank_proto_rawDesc = []byte{
 	0x0a, 0x15, 0x63, 0x6f, 0x75, 0x6e, 0x74, 0x43, 0x6c, 0x65, 0x61, 0x6e, 0x69, 0x6e, 0x67, 0x52,
 	0x65, 0x71, 0x75, 0x69, 0x72, 0x65, 0x64, 0x18, 0x03, 0x20, 0x01, 0x28, 0x03, 0x52, 0x15, 0x63,
 	0x6f, 0x75, 0x6e, 0x74, 0x43, 0x6c, 0x65, 0x61, 0x6e, 0x69, 0x6e, 0x67, 0x52, 0x65, 0x71, 0x75,
-	0x69, 0x72, 0x65, 0x64, 0x22, 0x8d, 0x01, 0x0a, 0x11, 0x43, 0x72, 0x65, 0x61, 0x74, 0x65, 0x54,
+	0x69, 0x72, 0x65, 0x64, 0x22, 0xeb, 0x01, 0x0a, 0x11, 0x43, 0x72, 0x65, 0x61, 0x74, 0x65, 0x54,
 	0x61, 0x6e, 0x6b, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x12, 0x16, 0x0a, 0x06, 0x73, 0x79,
 	0x73, 0x74, 0x65, 0x6d, 0x18, 0x01, 0x20, 0x01, 0x28, 0x09, 0x52, 0x06, 0x73, 0x79, 0x73, 0x74,
 	0x65, 0x6d, 0x12, 0x16, 0x0a, 0x06, 0x6e, 0x75, 0x6d, 0x62, 0x65, 0x72, 0x18, 0x02, 0x20, 0x01,
@@ -752,55 +2149,142 @@ var file_tank_proto_rawDesc = []byte{
 	0x76, 0x65, 0x12, 0x12, 0x0a, 0x04, 0x73, 0x69, 0x7a, 0x65, 0x18, 0x04, 0x20, 0x01, 0x28, 0x0d,
 	0x52, 0x04, 0x73, 0x69, 0x7a, 0x65, 0x12, 0x1c, 0x0a, 0x09, 0x66, 0x69, 0x73, 0x68, 0x43, 0x6f,
 	0x75, 0x6e, 0x74, 0x18, 0x05, 0x20, 0x01, 0x28, 0x0d, 0x52, 0x09, 0x66, 0x69, 0x73, 0x68, 0x43,
-	0x6f, 0x75, 0x6e, 0x74, 0x22, 0x14, 0x0a, 0x12, 0x43, 0x72, 0x65, 0x61, 0x74, 0x65, 0x54, 0x61,
-	0x6e, 0x6b, 0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x22, 0x89, 0x01, 0x0a, 0x11, 0x55,
-	0x70, 0x64, 0x61, 0x74, 0x65, 0x54, 0x61, 0x6e, 0x6b, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74,
-	0x12, 0x16, 0x0a, 0x06, 0x6e, 0x75, 0x6d, 0x62, 0x65, 0x72, 0x18, 0x01, 0x20, 0x01, 0x28, 0x0d,
-	0x52, 0x06, 0x6e, 0x75, 0x6d, 0x62, 0x65, 0x72, 0x12, 0x2c, 0x0a, 0x04, 0x74, 0x61, 0x6e, 0x6b,
-	0x18, 0x02, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x18, 0x2e, 0x61, 0x6e, 0x63, 0x68, 0x61, 0x6d, 0x62,
-	0x65, 0x72, 0x2e, 0x67, 0x65, 0x6e, 0x65, 0x74, 0x69, 0x63, 0x73, 0x2e, 0x54, 0x61, 0x6e, 0x6b,
-	0x52, 0x04, 0x74, 0x61, 0x6e, 0x6b, 0x12, 0x2e, 0x0a, 0x04, 0x6d, 0x61, 0x73, 0x6b, 0x18, 0x03,
-	0x20, 0x01, 0x28, 0x0b, 0x32, 0x1a, 0x2e, 0x67, 0x6f, 0x6f, 0x67, 0x6c, 0x65, 0x2e, 0x70, 0x72,
-	0x6f, 0x74, 0x6f, 0x62, 0x75, 0x66, 0x2e, 0x46, 0x69, 0x65, 0x6c, 0x64, 0x4d, 0x61, 0x73, 0x6b,
-	0x52, 0x04, 0x6d, 0x61, 0x73, 0x6b, 0x22, 0x14, 0x0a, 0x12, 0x55, 0x70, 0x64, 0x61, 0x74, 0x65,
-	0x54, 0x61, 0x6e, 0x6b, 0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x22, 0x2b, 0x0a, 0x11,
-	0x44, 0x65, 0x6c, 0x65, 0x74, 0x65, 0x54, 0x61, 0x6e, 0x6b, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73,
-	0x74, 0x12, 0x16, 0x0a, 0x06, 0x6e, 0x75, 0x6d, 0x62, 0x65, 0x72, 0x18, 0x01, 0x20, 0x01, 0x28,
-	0x0d, 0x52, 0x06, 0x6e, 0x75, 0x6d, 0x62, 0x65, 0x72, 0x22, 0x14, 0x0a, 0x12, 0x44, 0x65, 0x6c,
-	0x65, 0x74, 0x65, 0x54, 0x61, 0x6e, 0x6b, 0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x32,
-	0xda, 0x03, 0x0a, 0x0b, 0x54, 0x61, 0x6e, 0x6b, 0x53, 0x65, 0x72, 0x76, 0x69, 0x63, 0x65, 0x12,
-	0x5b, 0x0a, 0x0b, 0x53, 0x74, 0x72, 0x65, 0x61, 0x6d, 0x54, 0x61, 0x6e, 0x6b, 0x73, 0x12, 0x26,
-	0x2e, 0x61, 0x6e, 0x63, 0x68, 0x61, 0x6d, 0x62, 0x65, 0x72, 0x2e, 0x67, 0x65, 0x6e, 0x65, 0x74,
-	0x69, 0x63, 0x73, 0x2e, 0x53, 0x74, 0x72, 0x65, 0x61, 0x6d, 0x54, 0x61, 0x6e, 0x6b, 0x73, 0x52,
-	0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x1a, 0x20, 0x2e, 0x61, 0x6e, 0x63, 0x68, 0x61, 0x6d, 0x62,
-	0x65, 0x72, 0x2e, 0x67, 0x65, 0x6e, 0x65, 0x74, 0x69, 0x63, 0x73, 0x2e, 0x54, 0x61, 0x6e, 0x6b,
-	0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x22, 0x00, 0x30, 0x01, 0x12, 0x51, 0x0a, 0x07,
-	0x47, 0x65, 0x74, 0x54, 0x61, 0x6e, 0x6b, 0x12, 0x22, 0x2e, 0x61, 0x6e, 0x63, 0x68, 0x61, 0x6d,
-	0x62, 0x65, 0x72, 0x2e, 0x67, 0x65, 0x6e, 0x65, 0x74, 0x69, 0x63, 0x73, 0x2e, 0x47, 0x65, 0x74,
-	0x54, 0x61, 0x6e, 0x6b, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x1a, 0x20, 0x2e, 0x61, 0x6e,
-	0x63, 0x68, 0x61, 0x6d, 0x62, 0x65, 0x72, 0x2e, 0x67, 0x65, 0x6e, 0x65, 0x74, 0x69, 0x63, 0x73,
-	0x2e, 0x54, 0x61, 0x6e, 0x6b, 0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x22, 0x00, 0x12,
-	0x5d, 0x0a, 0x0a, 0x43, 0x72, 0x65, 0x61, 0x74, 0x65, 0x54, 0x61, 0x6e, 0x6b, 0x12, 0x25, 0x2e,
+	0x6f, 0x75, 0x6e, 0x74, 0x12, 0x10, 0x0a, 0x03, 0x6c, 0x61, 0x62, 0x18, 0x06, 0x20, 0x01, 0x28,
+	0x09, 0x52, 0x03, 0x6c, 0x61, 0x62, 0x12, 0x12, 0x0a, 0x04, 0x6c, 0x69, 0x6e, 0x65, 0x18, 0x07,
+	0x20, 0x01, 0x28, 0x09, 0x52, 0x04, 0x6c, 0x69, 0x6e, 0x65, 0x12, 0x14, 0x0a, 0x05, 0x6e, 0x6f,
+	0x74, 0x65, 0x73, 0x18, 0x08, 0x20, 0x01, 0x28, 0x09, 0x52, 0x05, 0x6e, 0x6f, 0x74, 0x65, 0x73,
+	0x12, 0x20, 0x0a, 0x0b, 0x72, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x69, 0x62, 0x6c, 0x65, 0x18,
+	0x09, 0x20, 0x01, 0x28, 0x09, 0x52, 0x0b, 0x72, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x69, 0x62,
+	0x6c, 0x65, 0x22, 0x14, 0x0a, 0x12, 0x43, 0x72, 0x65, 0x61, 0x74, 0x65, 0x54, 0x61, 0x6e, 0x6b,
+	0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x22, 0x89, 0x01, 0x0a, 0x11, 0x55, 0x70, 0x64,
+	0x61, 0x74, 0x65, 0x54, 0x61, 0x6e, 0x6b, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x12, 0x16,
+	0x0a, 0x06, 0x6e, 0x75, 0x6d, 0x62, 0x65, 0x72, 0x18, 0x01, 0x20, 0x01, 0x28, 0x0d, 0x52, 0x06,
+	0x6e, 0x75, 0x6d, 0x62, 0x65, 0x72, 0x12, 0x2c, 0x0a, 0x04, 0x74, 0x61, 0x6e, 0x6b, 0x18, 0x02,
+	0x20, 0x01, 0x28, 0x0b, 0x32, 0x18, 0x2e, 0x61, 0x6e, 0x63, 0x68, 0x61, 0x6d, 0x62, 0x65, 0x72,
+	0x2e, 0x67, 0x65, 0x6e, 0x65, 0x74, 0x69, 0x63, 0x73, 0x2e, 0x54, 0x61, 0x6e, 0x6b, 0x52, 0x04,
+	0x74, 0x61, 0x6e, 0x6b, 0x12, 0x2e, 0x0a, 0x04, 0x6d, 0x61, 0x73, 0x6b, 0x18, 0x03, 0x20, 0x01,
+	0x28, 0x0b, 0x32, 0x1a, 0x2e, 0x67, 0x6f, 0x6f, 0x67, 0x6c, 0x65, 0x2e, 0x70, 0x72, 0x6f, 0x74,
+	0x6f, 0x62, 0x75, 0x66, 0x2e, 0x46, 0x69, 0x65, 0x6c, 0x64, 0x4d, 0x61, 0x73, 0x6b, 0x52, 0x04,
+	0x6d, 0x61, 0x73, 0x6b, 0x22, 0x14, 0x0a, 0x12, 0x55, 0x70, 0x64, 0x61, 0x74, 0x65, 0x54, 0x61,
+	0x6e, 0x6b, 0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x22, 0x2b, 0x0a, 0x11, 0x44, 0x65,
+	0x6c, 0x65, 0x74, 0x65, 0x54, 0x61, 0x6e, 0x6b, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x12,
+	0x16, 0x0a, 0x06, 0x6e, 0x75, 0x6d, 0x62, 0x65, 0x72, 0x18, 0x01, 0x20, 0x01, 0x28, 0x0d, 0x52,
+	0x06, 0x6e, 0x75, 0x6d, 0x62, 0x65, 0x72, 0x22, 0x14, 0x0a, 0x12, 0x44, 0x65, 0x6c, 0x65, 0x74,
+	0x65, 0x54, 0x61, 0x6e, 0x6b, 0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x22, 0xbf, 0x02,
+	0x0a, 0x19, 0x47, 0x65, 0x6e, 0x65, 0x72, 0x61, 0x74, 0x65, 0x54, 0x61, 0x6e, 0x6b, 0x4c, 0x61,
+	0x62, 0x65, 0x6c, 0x73, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x12, 0x18, 0x0a, 0x07, 0x6e,
+	0x75, 0x6d, 0x62, 0x65, 0x72, 0x73, 0x18, 0x01, 0x20, 0x03, 0x28, 0x0d, 0x52, 0x07, 0x6e, 0x75,
+	0x6d, 0x62, 0x65, 0x72, 0x73, 0x12, 0x38, 0x0a, 0x07, 0x66, 0x69, 0x6c, 0x74, 0x65, 0x72, 0x73,
+	0x18, 0x02, 0x20, 0x03, 0x28, 0x0b, 0x32, 0x1e, 0x2e, 0x61, 0x6e, 0x63, 0x68, 0x61, 0x6d, 0x62,
+	0x65, 0x72, 0x2e, 0x67, 0x65, 0x6e, 0x65, 0x74, 0x69, 0x63, 0x73, 0x2e, 0x61, 0x70, 0x69, 0x2e,
+	0x46, 0x69, 0x6c, 0x74, 0x65, 0x72, 0x52, 0x07, 0x66, 0x69, 0x6c, 0x74, 0x65, 0x72, 0x73, 0x12,
+	0x3c, 0x0a, 0x06, 0x66, 0x69, 0x6c, 0x74, 0x65, 0x72, 0x18, 0x03, 0x20, 0x01, 0x28, 0x0b, 0x32,
+	0x24, 0x2e, 0x61, 0x6e, 0x63, 0x68, 0x61, 0x6d, 0x62, 0x65, 0x72, 0x2e, 0x67, 0x65, 0x6e, 0x65,
+	0x74, 0x69, 0x63, 0x73, 0x2e, 0x46, 0x69, 0x6c, 0x74, 0x65, 0x72, 0x45, 0x78, 0x70, 0x72, 0x65,
+	0x73, 0x73, 0x69, 0x6f, 0x6e, 0x52, 0x06, 0x66, 0x69, 0x6c, 0x74, 0x65, 0x72, 0x12, 0x37, 0x0a,
+	0x06, 0x66, 0x6f, 0x72, 0x6d, 0x61, 0x74, 0x18, 0x04, 0x20, 0x01, 0x28, 0x0e, 0x32, 0x1f, 0x2e,
 	0x61, 0x6e, 0x63, 0x68, 0x61, 0x6d, 0x62, 0x65, 0x72, 0x2e, 0x67, 0x65, 0x6e, 0x65, 0x74, 0x69,
-	0x63, 0x73, 0x2e, 0x43, 0x72, 0x65, 0x61, 0x74, 0x65, 0x54, 0x61, 0x6e, 0x6b, 0x52, 0x65, 0x71,
-	0x75, 0x65, 0x73, 0x74, 0x1a, 0x26, 0x2e, 0x61, 0x6e, 0x63, 0x68, 0x61, 0x6d, 0x62, 0x65, 0x72,
-	0x2e, 0x67, 0x65, 0x6e, 0x65, 0x74, 0x69, 0x63, 0x73, 0x2e, 0x43, 0x72, 0x65, 0x61, 0x74, 0x65,
-	0x54, 0x61, 0x6e, 0x6b, 0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x22, 0x00, 0x12, 0x5d,
-	0x0a, 0x0a, 0x55, 0x70, 0x64, 0x61, 0x74, 0x65, 0x54, 0x61, 0x6e, 0x6b, 0x12, 0x25, 0x2e, 0x61,
+	0x63, 0x73, 0x2e, 0x4c, 0x61, 0x62, 0x65, 0x6c, 0x46, 0x6f, 0x72, 0x6d, 0x61, 0x74, 0x52, 0x06,
+	0x66, 0x6f, 0x72, 0x6d, 0x61, 0x74, 0x12, 0x3b, 0x0a, 0x09, 0x73, 0x79, 0x6d, 0x62, 0x6f, 0x6c,
+	0x6f, 0x67, 0x79, 0x18, 0x05, 0x20, 0x01, 0x28, 0x0e, 0x32, 0x1d, 0x2e, 0x61, 0x6e, 0x63, 0x68,
+	0x61, 0x6d, 0x62, 0x65, 0x72, 0x2e, 0x67, 0x65, 0x6e, 0x65, 0x74, 0x69, 0x63, 0x73, 0x2e, 0x53,
+	0x79, 0x6d, 0x62, 0x6f, 0x6c, 0x6f, 0x67, 0x79, 0x52, 0x09, 0x73, 0x79, 0x6d, 0x62, 0x6f, 0x6c,
+	0x6f, 0x67, 0x79, 0x12, 0x1a, 0x0a, 0x08, 0x74, 0x65, 0x6d, 0x70, 0x6c, 0x61, 0x74, 0x65, 0x18,
+	0x06, 0x20, 0x01, 0x28, 0x09, 0x52, 0x08, 0x74, 0x65, 0x6d, 0x70, 0x6c, 0x61, 0x74, 0x65, 0x22,
+	0x54, 0x0a, 0x1a, 0x47, 0x65, 0x6e, 0x65, 0x72, 0x61, 0x74, 0x65, 0x54, 0x61, 0x6e, 0x6b, 0x4c,
+	0x61, 0x62, 0x65, 0x6c, 0x73, 0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x12, 0x20, 0x0a,
+	0x0b, 0x63, 0x6f, 0x6e, 0x74, 0x65, 0x6e, 0x74, 0x54, 0x79, 0x70, 0x65, 0x18, 0x01, 0x20, 0x01,
+	0x28, 0x09, 0x52, 0x0b, 0x63, 0x6f, 0x6e, 0x74, 0x65, 0x6e, 0x74, 0x54, 0x79, 0x70, 0x65, 0x12,
+	0x14, 0x0a, 0x05, 0x70, 0x61, 0x67, 0x65, 0x73, 0x18, 0x02, 0x20, 0x03, 0x28, 0x0c, 0x52, 0x05,
+	0x70, 0x61, 0x67, 0x65, 0x73, 0x22, 0x66, 0x0a, 0x12, 0x52, 0x65, 0x73, 0x6f, 0x6c, 0x76, 0x65,
+	0x53, 0x63, 0x61, 0x6e, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x12, 0x18, 0x0a, 0x07, 0x70,
+	0x61, 0x79, 0x6c, 0x6f, 0x61, 0x64, 0x18, 0x01, 0x20, 0x01, 0x28, 0x09, 0x52, 0x07, 0x70, 0x61,
+	0x79, 0x6c, 0x6f, 0x61, 0x64, 0x12, 0x36, 0x0a, 0x08, 0x72, 0x65, 0x61, 0x64, 0x4d, 0x61, 0x73,
+	0x6b, 0x18, 0x02, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x1a, 0x2e, 0x67, 0x6f, 0x6f, 0x67, 0x6c, 0x65,
+	0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x62, 0x75, 0x66, 0x2e, 0x46, 0x69, 0x65, 0x6c, 0x64, 0x4d,
+	0x61, 0x73, 0x6b, 0x52, 0x08, 0x72, 0x65, 0x61, 0x64, 0x4d, 0x61, 0x73, 0x6b, 0x22, 0x7b, 0x0a,
+	0x11, 0x53, 0x63, 0x61, 0x6e, 0x41, 0x63, 0x74, 0x69, 0x6f, 0x6e, 0x52, 0x65, 0x71, 0x75, 0x65,
+	0x73, 0x74, 0x12, 0x18, 0x0a, 0x07, 0x70, 0x61, 0x79, 0x6c, 0x6f, 0x61, 0x64, 0x18, 0x01, 0x20,
+	0x01, 0x28, 0x09, 0x52, 0x07, 0x70, 0x61, 0x79, 0x6c, 0x6f, 0x61, 0x64, 0x12, 0x36, 0x0a, 0x06,
+	0x61, 0x63, 0x74, 0x69, 0x6f, 0x6e, 0x18, 0x02, 0x20, 0x01, 0x28, 0x0e, 0x32, 0x1e, 0x2e, 0x61,
 	0x6e, 0x63, 0x68, 0x61, 0x6d, 0x62, 0x65, 0x72, 0x2e, 0x67, 0x65, 0x6e, 0x65, 0x74, 0x69, 0x63,
-	0x73, 0x2e, 0x55, 0x70, 0x64, 0x61, 0x74, 0x65, 0x54, 0x61, 0x6e, 0x6b, 0x52, 0x65, 0x71, 0x75,
-	0x65, 0x73, 0x74, 0x1a, 0x26, 0x2e, 0x61, 0x6e, 0x63, 0x68, 0x61, 0x6d, 0x62, 0x65, 0x72, 0x2e,
-	0x67, 0x65, 0x6e, 0x65, 0x74, 0x69, 0x63, 0x73, 0x2e, 0x55, 0x70, 0x64, 0x61, 0x74, 0x65, 0x54,
-	0x61, 0x6e, 0x6b, 0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x22, 0x00, 0x12, 0x5d, 0x0a,
-	0x0a, 0x44, 0x65, 0x6c, 0x65, 0x74, 0x65, 0x54, 0x61, 0x6e, 0x6b, 0x12, 0x25, 0x2e, 0x61, 0x6e,
+	0x73, 0x2e, 0x54, 0x61, 0x6e, 0x6b, 0x41, 0x63, 0x74, 0x69, 0x6f, 0x6e, 0x52, 0x06, 0x61, 0x63,
+	0x74, 0x69, 0x6f, 0x6e, 0x12, 0x14, 0x0a, 0x05, 0x63, 0x6f, 0x75, 0x6e, 0x74, 0x18, 0x03, 0x20,
+	0x01, 0x28, 0x0d, 0x52, 0x05, 0x63, 0x6f, 0x75, 0x6e, 0x74, 0x2a, 0x28, 0x0a, 0x0b, 0x4c, 0x61,
+	0x62, 0x65, 0x6c, 0x46, 0x6f, 0x72, 0x6d, 0x61, 0x74, 0x12, 0x07, 0x0a, 0x03, 0x53, 0x56, 0x47,
+	0x10, 0x00, 0x12, 0x07, 0x0a, 0x03, 0x50, 0x4e, 0x47, 0x10, 0x01, 0x12, 0x07, 0x0a, 0x03, 0x5a,
+	0x50, 0x4c, 0x10, 0x02, 0x2a, 0x20, 0x0a, 0x09, 0x53, 0x79, 0x6d, 0x62, 0x6f, 0x6c, 0x6f, 0x67,
+	0x79, 0x12, 0x0b, 0x0a, 0x07, 0x43, 0x4f, 0x44, 0x45, 0x31, 0x32, 0x38, 0x10, 0x00, 0x12, 0x06,
+	0x0a, 0x02, 0x51, 0x52, 0x10, 0x01, 0x2a, 0x4e, 0x0a, 0x0a, 0x54, 0x61, 0x6e, 0x6b, 0x41, 0x63,
+	0x74, 0x69, 0x6f, 0x6e, 0x12, 0x1b, 0x0a, 0x17, 0x54, 0x41, 0x4e, 0x4b, 0x5f, 0x41, 0x43, 0x54,
+	0x49, 0x4f, 0x4e, 0x5f, 0x55, 0x4e, 0x53, 0x50, 0x45, 0x43, 0x49, 0x46, 0x49, 0x45, 0x44, 0x10,
+	0x00, 0x12, 0x0b, 0x0a, 0x07, 0x43, 0x4c, 0x45, 0x41, 0x4e, 0x45, 0x44, 0x10, 0x01, 0x12, 0x07,
+	0x0a, 0x03, 0x46, 0x45, 0x44, 0x10, 0x02, 0x12, 0x0d, 0x0a, 0x09, 0x4d, 0x4f, 0x52, 0x54, 0x41,
+	0x4c, 0x49, 0x54, 0x59, 0x10, 0x03, 0x32, 0xc6, 0x07, 0x0a, 0x0b, 0x54, 0x61, 0x6e, 0x6b, 0x53,
+	0x65, 0x72, 0x76, 0x69, 0x63, 0x65, 0x12, 0x5b, 0x0a, 0x0b, 0x53, 0x74, 0x72, 0x65, 0x61, 0x6d,
+	0x54, 0x61, 0x6e, 0x6b, 0x73, 0x12, 0x26, 0x2e, 0x61, 0x6e, 0x63, 0x68, 0x61, 0x6d, 0x62, 0x65,
+	0x72, 0x2e, 0x67, 0x65, 0x6e, 0x65, 0x74, 0x69, 0x63, 0x73, 0x2e, 0x53, 0x74, 0x72, 0x65, 0x61,
+	0x6d, 0x54, 0x61, 0x6e, 0x6b, 0x73, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x1a, 0x20, 0x2e,
+	0x61, 0x6e, 0x63, 0x68, 0x61, 0x6d, 0x62, 0x65, 0x72, 0x2e, 0x67, 0x65, 0x6e, 0x65, 0x74, 0x69,
+	0x63, 0x73, 0x2e, 0x54, 0x61, 0x6e, 0x6b, 0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x22,
+	0x00, 0x30, 0x01, 0x12, 0x5d, 0x0a, 0x0a, 0x43, 0x6f, 0x75, 0x6e, 0x74, 0x54, 0x61, 0x6e, 0x6b,
+	0x73, 0x12, 0x25, 0x2e, 0x61, 0x6e, 0x63, 0x68, 0x61, 0x6d, 0x62, 0x65, 0x72, 0x2e, 0x67, 0x65,
+	0x6e, 0x65, 0x74, 0x69, 0x63, 0x73, 0x2e, 0x43, 0x6f, 0x75, 0x6e, 0x74, 0x54, 0x61, 0x6e, 0x6b,
+	0x73, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x1a, 0x26, 0x2e, 0x61, 0x6e, 0x63, 0x68, 0x61,
+	0x6d, 0x62, 0x65, 0x72, 0x2e, 0x67, 0x65, 0x6e, 0x65, 0x74, 0x69, 0x63, 0x73, 0x2e, 0x43, 0x6f,
+	0x75, 0x6e, 0x74, 0x54, 0x61, 0x6e, 0x6b, 0x73, 0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65,
+	0x22, 0x00, 0x12, 0x60, 0x0a, 0x0b, 0x53, 0x65, 0x61, 0x72, 0x63, 0x68, 0x54, 0x61, 0x6e, 0x6b,
+	0x73, 0x12, 0x26, 0x2e, 0x61, 0x6e, 0x63, 0x68, 0x61, 0x6d, 0x62, 0x65, 0x72, 0x2e, 0x67, 0x65,
+	0x6e, 0x65, 0x74, 0x69, 0x63, 0x73, 0x2e, 0x53, 0x65, 0x61, 0x72, 0x63, 0x68, 0x54, 0x61, 0x6e,
+	0x6b, 0x73, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x1a, 0x27, 0x2e, 0x61, 0x6e, 0x63, 0x68,
+	0x61, 0x6d, 0x62, 0x65, 0x72, 0x2e, 0x67, 0x65, 0x6e, 0x65, 0x74, 0x69, 0x63, 0x73, 0x2e, 0x53,
+	0x65, 0x61, 0x72, 0x63, 0x68, 0x54, 0x61, 0x6e, 0x6b, 0x73, 0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e,
+	0x73, 0x65, 0x22, 0x00, 0x12, 0x51, 0x0a, 0x07, 0x47, 0x65, 0x74, 0x54, 0x61, 0x6e, 0x6b, 0x12,
+	0x22, 0x2e, 0x61, 0x6e, 0x63, 0x68, 0x61, 0x6d, 0x62, 0x65, 0x72, 0x2e, 0x67, 0x65, 0x6e, 0x65,
+	0x74, 0x69, 0x63, 0x73, 0x2e, 0x47, 0x65, 0x74, 0x54, 0x61, 0x6e, 0x6b, 0x52, 0x65, 0x71, 0x75,
+	0x65, 0x73, 0x74, 0x1a, 0x20, 0x2e, 0x61, 0x6e, 0x63, 0x68, 0x61, 0x6d, 0x62, 0x65, 0x72, 0x2e,
+	0x67, 0x65, 0x6e, 0x65, 0x74, 0x69, 0x63, 0x73, 0x2e, 0x54, 0x61, 0x6e, 0x6b, 0x52, 0x65, 0x73,
+	0x70, 0x6f, 0x6e, 0x73, 0x65, 0x22, 0x00, 0x12, 0x5d, 0x0a, 0x0a, 0x43, 0x72, 0x65, 0x61, 0x74,
+	0x65, 0x54, 0x61, 0x6e, 0x6b, 0x12, 0x25, 0x2e, 0x61, 0x6e, 0x63, 0x68, 0x61, 0x6d, 0x62, 0x65,
+	0x72, 0x2e, 0x67, 0x65, 0x6e, 0x65, 0x74, 0x69, 0x63, 0x73, 0x2e, 0x43, 0x72, 0x65, 0x61, 0x74,
+	0x65, 0x54, 0x61, 0x6e, 0x6b, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x1a, 0x26, 0x2e, 0x61,
+	0x6e, 0x63, 0x68, 0x61, 0x6d, 0x62, 0x65, 0x72, 0x2e, 0x67, 0x65, 0x6e, 0x65, 0x74, 0x69, 0x63,
+	0x73, 0x2e, 0x43, 0x72, 0x65, 0x61, 0x74, 0x65, 0x54, 0x61, 0x6e, 0x6b, 0x52, 0x65, 0x73, 0x70,
+	0x6f, 0x6e, 0x73, 0x65, 0x22, 0x00, 0x12, 0x5d, 0x0a, 0x0a, 0x55, 0x70, 0x64, 0x61, 0x74, 0x65,
+	0x54, 0x61, 0x6e, 0x6b, 0x12, 0x25, 0x2e, 0x61, 0x6e, 0x63, 0x68, 0x61, 0x6d, 0x62, 0x65, 0x72,
+	0x2e, 0x67, 0x65, 0x6e, 0x65, 0x74, 0x69, 0x63, 0x73, 0x2e, 0x55, 0x70, 0x64, 0x61, 0x74, 0x65,
+	0x54, 0x61, 0x6e, 0x6b, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x1a, 0x26, 0x2e, 0x61, 0x6e,
 	0x63, 0x68, 0x61, 0x6d, 0x62, 0x65, 0x72, 0x2e, 0x67, 0x65, 0x6e, 0x65, 0x74, 0x69, 0x63, 0x73,
-	0x2e, 0x44, 0x65, 0x6c, 0x65, 0x74, 0x65, 0x54, 0x61, 0x6e, 0x6b, 0x52, 0x65, 0x71, 0x75, 0x65,
-	0x73, 0x74, 0x1a, 0x26, 0x2e, 0x61, 0x6e, 0x63, 0x68, 0x61, 0x6d, 0x62, 0x65, 0x72, 0x2e, 0x67,
-	0x65, 0x6e, 0x65, 0x74, 0x69, 0x63, 0x73, 0x2e, 0x44, 0x65, 0x6c, 0x65, 0x74, 0x65, 0x54, 0x61,
-	0x6e, 0x6b, 0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x22, 0x00, 0x42, 0x2a, 0x5a, 0x28,
-	0x67, 0x69, 0x74, 0x68, 0x75, 0x62, 0x2e, 0x63, 0x6f, 0x6d, 0x2f, 0x61, 0x6e, 0x63, 0x68, 0x61,
-	0x6d, 0x62, 0x65, 0x72, 0x2f, 0x67, 0x65, 0x6e, 0x65, 0x74, 0x69, 0x63, 0x73, 0x2d, 0x74, 0x61,
-	0x6e, 0x6b, 0x2f, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x62, 0x06, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x33,
+	0x2e, 0x55, 0x70, 0x64, 0x61, 0x74, 0x65, 0x54, 0x61, 0x6e, 0x6b, 0x52, 0x65, 0x73, 0x70, 0x6f,
+	0x6e, 0x73, 0x65, 0x22, 0x00, 0x12, 0x5d, 0x0a, 0x0a, 0x44, 0x65, 0x6c, 0x65, 0x74, 0x65, 0x54,
+	0x61, 0x6e, 0x6b, 0x12, 0x25, 0x2e, 0x61, 0x6e, 0x63, 0x68, 0x61, 0x6d, 0x62, 0x65, 0x72, 0x2e,
+	0x67, 0x65, 0x6e, 0x65, 0x74, 0x69, 0x63, 0x73, 0x2e, 0x44, 0x65, 0x6c, 0x65, 0x74, 0x65, 0x54,
+	0x61, 0x6e, 0x6b, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x1a, 0x26, 0x2e, 0x61, 0x6e, 0x63,
+	0x68, 0x61, 0x6d, 0x62, 0x65, 0x72, 0x2e, 0x67, 0x65, 0x6e, 0x65, 0x74, 0x69, 0x63, 0x73, 0x2e,
+	0x44, 0x65, 0x6c, 0x65, 0x74, 0x65, 0x54, 0x61, 0x6e, 0x6b, 0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e,
+	0x73, 0x65, 0x22, 0x00, 0x12, 0x75, 0x0a, 0x12, 0x47, 0x65, 0x6e, 0x65, 0x72, 0x61, 0x74, 0x65,
+	0x54, 0x61, 0x6e, 0x6b, 0x4c, 0x61, 0x62, 0x65, 0x6c, 0x73, 0x12, 0x2d, 0x2e, 0x61, 0x6e, 0x63,
+	0x68, 0x61, 0x6d, 0x62, 0x65, 0x72, 0x2e, 0x67, 0x65, 0x6e, 0x65, 0x74, 0x69, 0x63, 0x73, 0x2e,
+	0x47, 0x65, 0x6e, 0x65, 0x72, 0x61, 0x74, 0x65, 0x54, 0x61, 0x6e, 0x6b, 0x4c, 0x61, 0x62, 0x65,
+	0x6c, 0x73, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x1a, 0x2e, 0x2e, 0x61, 0x6e, 0x63, 0x68,
+	0x61, 0x6d, 0x62, 0x65, 0x72, 0x2e, 0x67, 0x65, 0x6e, 0x65, 0x74, 0x69, 0x63, 0x73, 0x2e, 0x47,
+	0x65, 0x6e, 0x65, 0x72, 0x61, 0x74, 0x65, 0x54, 0x61, 0x6e, 0x6b, 0x4c, 0x61, 0x62, 0x65, 0x6c,
+	0x73, 0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x22, 0x00, 0x12, 0x59, 0x0a, 0x0b, 0x52,
+	0x65, 0x73, 0x6f, 0x6c, 0x76, 0x65, 0x53, 0x63, 0x61, 0x6e, 0x12, 0x26, 0x2e, 0x61, 0x6e, 0x63,
+	0x68, 0x61, 0x6d, 0x62, 0x65, 0x72, 0x2e, 0x67, 0x65, 0x6e, 0x65, 0x74, 0x69, 0x63, 0x73, 0x2e,
+	0x52, 0x65, 0x73, 0x6f, 0x6c, 0x76, 0x65, 0x53, 0x63, 0x61, 0x6e, 0x52, 0x65, 0x71, 0x75, 0x65,
+	0x73, 0x74, 0x1a, 0x20, 0x2e, 0x61, 0x6e, 0x63, 0x68, 0x61, 0x6d, 0x62, 0x65, 0x72, 0x2e, 0x67,
+	0x65, 0x6e, 0x65, 0x74, 0x69, 0x63, 0x73, 0x2e, 0x54, 0x61, 0x6e, 0x6b, 0x52, 0x65, 0x73, 0x70,
+	0x6f, 0x6e, 0x73, 0x65, 0x22, 0x00, 0x12, 0x57, 0x0a, 0x0a, 0x53, 0x63, 0x61, 0x6e, 0x41, 0x63,
+	0x74, 0x69, 0x6f, 0x6e, 0x12, 0x25, 0x2e, 0x61, 0x6e, 0x63, 0x68, 0x61, 0x6d, 0x62, 0x65, 0x72,
+	0x2e, 0x67, 0x65, 0x6e, 0x65, 0x74, 0x69, 0x63, 0x73, 0x2e, 0x53, 0x63, 0x61, 0x6e, 0x41, 0x63,
+	0x74, 0x69, 0x6f, 0x6e, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x1a, 0x20, 0x2e, 0x61, 0x6e,
+	0x63, 0x68, 0x61, 0x6d, 0x62, 0x65, 0x72, 0x2e, 0x67, 0x65, 0x6e, 0x65, 0x74, 0x69, 0x63, 0x73,
+	0x2e, 0x54, 0x61, 0x6e, 0x6b, 0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x22, 0x00, 0x42,
+	0x2a, 0x5a, 0x28, 0x67, 0x69, 0x74, 0x68, 0x75, 0x62, 0x2e, 0x63, 0x6f, 0x6d, 0x2f, 0x61, 0x6e,
+	0x63, 0x68, 0x61, 0x6d, 0x62, 0x65, 0x72, 0x2f, 0x67, 0x65, 0x6e, 0x65, 0x74, 0x69, 0x63, 0x73,
+	0x2d, 0x74, 0x61, 0x6e, 0x6b, 0x2f, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x62, 0x06, 0x70, 0x72, 0x6f,
+	0x74, 0x6f, 0x33,
 }
 
 var (
@@ -815,44 +2299,95 @@ func file_tank_proto_rawDescGZIP() []byte {
 	return file_tank_proto_rawDescData
 }
 
-var file_tank_proto_msgTypes = make([]protoimpl.MessageInfo, 12)
+var file_tank_proto_enumTypes = make([]protoimpl.EnumInfo, 3)
+var file_tank_proto_msgTypes = make([]protoimpl.MessageInfo, 27)
 var file_tank_proto_goTypes = []interface{}{
-	(*Tank)(nil),                  // 0: anchamber.genetics.Tank
-	(*StreamTanksRequest)(nil),    // 1: anchamber.genetics.StreamTanksRequest
-	(*GetTankRequest)(nil),        // 2: anchamber.genetics.GetTankRequest
-	(*TankResponse)(nil),          // 3: anchamber.genetics.TankResponse
-	(*GetTankStatsRequest)(nil),   // 4: anchamber.genetics.GetTankStatsRequest
-	(*GetTankStatsResponse)(nil),  // 5: anchamber.genetics.GetTankStatsResponse
-	(*CreateTankRequest)(nil),     // 6: anchamber.genetics.CreateTankRequest
-	(*CreateTankResponse)(nil),    // 7: anchamber.genetics.CreateTankResponse
-	(*UpdateTankRequest)(nil),     // 8: anchamber.genetics.UpdateTankRequest
-	(*UpdateTankResponse)(nil),    // 9: anchamber.genetics.UpdateTankResponse
-	(*DeleteTankRequest)(nil),     // 10: anchamber.genetics.DeleteTankRequest
-	(*DeleteTankResponse)(nil),    // 11: anchamber.genetics.DeleteTankResponse
-	(*proto.Filter)(nil),          // 12: anchamber.genetics.api.Filter
-	(*proto.Pagination)(nil),      // 13: anchamber.genetics.api.Pagination
-	(*fieldmaskpb.FieldMask)(nil), // 14: google.protobuf.FieldMask
+	(LabelFormat)(0),                   // 0: anchamber.genetics.LabelFormat
+	(Symbology)(0),                     // 1: anchamber.genetics.Symbology
+	(TankAction)(0),                    // 2: anchamber.genetics.TankAction
+	(*Tank)(nil),                       // 3: anchamber.genetics.Tank
+	(*StreamTanksRequest)(nil),         // 4: anchamber.genetics.StreamTanksRequest
+	(*CountTanksRequest)(nil),          // 5: anchamber.genetics.CountTanksRequest
+	(*CountTanksResponse)(nil),         // 6: anchamber.genetics.CountTanksResponse
+	(*SearchTanksRequest)(nil),         // 7: anchamber.genetics.SearchTanksRequest
+	(*SearchTanksResponse)(nil),        // 8: anchamber.genetics.SearchTanksResponse
+	(*SearchResult)(nil),               // 9: anchamber.genetics.SearchResult
+	(*OrderBy)(nil),                    // 10: anchamber.genetics.OrderBy
+	(*FilterExpression)(nil),           // 11: anchamber.genetics.FilterExpression
+	(*FilterExpressionList)(nil),       // 12: anchamber.genetics.FilterExpressionList
+	(*InFilter)(nil),                   // 13: anchamber.genetics.InFilter
+	(*BetweenFilter)(nil),              // 14: anchamber.genetics.BetweenFilter
+	(*IsNullFilter)(nil),               // 15: anchamber.genetics.IsNullFilter
+	(*GetTankRequest)(nil),             // 16: anchamber.genetics.GetTankRequest
+	(*TankResponse)(nil),               // 17: anchamber.genetics.TankResponse
+	(*GetTankStatsRequest)(nil),        // 18: anchamber.genetics.GetTankStatsRequest
+	(*GetTankStatsResponse)(nil),       // 19: anchamber.genetics.GetTankStatsResponse
+	(*CreateTankRequest)(nil),          // 20: anchamber.genetics.CreateTankRequest
+	(*CreateTankResponse)(nil),         // 21: anchamber.genetics.CreateTankResponse
+	(*UpdateTankRequest)(nil),          // 22: anchamber.genetics.UpdateTankRequest
+	(*UpdateTankResponse)(nil),         // 23: anchamber.genetics.UpdateTankResponse
+	(*DeleteTankRequest)(nil),          // 24: anchamber.genetics.DeleteTankRequest
+	(*DeleteTankResponse)(nil),         // 25: anchamber.genetics.DeleteTankResponse
+	(*GenerateTankLabelsRequest)(nil),  // 26: anchamber.genetics.GenerateTankLabelsRequest
+	(*GenerateTankLabelsResponse)(nil), // 27: anchamber.genetics.GenerateTankLabelsResponse
+	(*ResolveScanRequest)(nil),         // 28: anchamber.genetics.ResolveScanRequest
+	(*ScanActionRequest)(nil),          // 29: anchamber.genetics.ScanActionRequest
+	(*proto.Filter)(nil),               // 30: anchamber.genetics.api.Filter
+	(*proto.Pagination)(nil),           // 31: anchamber.genetics.api.Pagination
+	(*fieldmaskpb.FieldMask)(nil),      // 32: google.protobuf.FieldMask
 }
 var file_tank_proto_depIdxs = []int32{
-	12, // 0: anchamber.genetics.StreamTanksRequest.filters:type_name -> anchamber.genetics.api.Filter
-	13, // 1: anchamber.genetics.StreamTanksRequest.pageination:type_name -> anchamber.genetics.api.Pagination
-	0,  // 2: anchamber.genetics.UpdateTankRequest.tank:type_name -> anchamber.genetics.Tank
-	14, // 3: anchamber.genetics.UpdateTankRequest.mask:type_name -> google.protobuf.FieldMask
-	1,  // 4: anchamber.genetics.TankService.StreamTanks:input_type -> anchamber.genetics.StreamTanksRequest
-	2,  // 5: anchamber.genetics.TankService.GetTank:input_type -> anchamber.genetics.GetTankRequest
-	6,  // 6: anchamber.genetics.TankService.CreateTank:input_type -> anchamber.genetics.CreateTankRequest
-	8,  // 7: anchamber.genetics.TankService.UpdateTank:input_type -> anchamber.genetics.UpdateTankRequest
-	10, // 8: anchamber.genetics.TankService.DeleteTank:input_type -> anchamber.genetics.DeleteTankRequest
-	3,  // 9: anchamber.genetics.TankService.StreamTanks:output_type -> anchamber.genetics.TankResponse
-	3,  // 10: anchamber.genetics.TankService.GetTank:output_type -> anchamber.genetics.TankResponse
-	7,  // 11: anchamber.genetics.TankService.CreateTank:output_type -> anchamber.genetics.CreateTankResponse
-	9,  // 12: anchamber.genetics.TankService.UpdateTank:output_type -> anchamber.genetics.UpdateTankResponse
-	11, // 13: anchamber.genetics.TankService.DeleteTank:output_type -> anchamber.genetics.DeleteTankResponse
-	9,  // [9:14] is the sub-list for method output_type
-	4,  // [4:9] is the sub-list for method input_type
-	4,  // [4:4] is the sub-list for extension type_name
-	4,  // [4:4] is the sub-list for extension extendee
-	0,  // [0:4] is the sub-list for field type_name
+	30, // 0: anchamber.genetics.StreamTanksRequest.filters:type_name -> anchamber.genetics.api.Filter
+	31, // 1: anchamber.genetics.StreamTanksRequest.pageination:type_name -> anchamber.genetics.api.Pagination
+	11, // 2: anchamber.genetics.StreamTanksRequest.filter:type_name -> anchamber.genetics.FilterExpression
+	10, // 3: anchamber.genetics.StreamTanksRequest.orderBy:type_name -> anchamber.genetics.OrderBy
+	32, // 4: anchamber.genetics.StreamTanksRequest.readMask:type_name -> google.protobuf.FieldMask
+	30, // 5: anchamber.genetics.CountTanksRequest.filters:type_name -> anchamber.genetics.api.Filter
+	11, // 6: anchamber.genetics.CountTanksRequest.filter:type_name -> anchamber.genetics.FilterExpression
+	9,  // 7: anchamber.genetics.SearchTanksResponse.results:type_name -> anchamber.genetics.SearchResult
+	17, // 8: anchamber.genetics.SearchResult.tank:type_name -> anchamber.genetics.TankResponse
+	12, // 9: anchamber.genetics.FilterExpression.and:type_name -> anchamber.genetics.FilterExpressionList
+	12, // 10: anchamber.genetics.FilterExpression.or:type_name -> anchamber.genetics.FilterExpressionList
+	11, // 11: anchamber.genetics.FilterExpression.not:type_name -> anchamber.genetics.FilterExpression
+	30, // 12: anchamber.genetics.FilterExpression.condition:type_name -> anchamber.genetics.api.Filter
+	13, // 13: anchamber.genetics.FilterExpression.in:type_name -> anchamber.genetics.InFilter
+	14, // 14: anchamber.genetics.FilterExpression.between:type_name -> anchamber.genetics.BetweenFilter
+	15, // 15: anchamber.genetics.FilterExpression.isNull:type_name -> anchamber.genetics.IsNullFilter
+	11, // 16: anchamber.genetics.FilterExpressionList.expressions:type_name -> anchamber.genetics.FilterExpression
+	32, // 17: anchamber.genetics.GetTankRequest.readMask:type_name -> google.protobuf.FieldMask
+	3,  // 18: anchamber.genetics.UpdateTankRequest.tank:type_name -> anchamber.genetics.Tank
+	32, // 19: anchamber.genetics.UpdateTankRequest.mask:type_name -> google.protobuf.FieldMask
+	30, // 20: anchamber.genetics.GenerateTankLabelsRequest.filters:type_name -> anchamber.genetics.api.Filter
+	11, // 21: anchamber.genetics.GenerateTankLabelsRequest.filter:type_name -> anchamber.genetics.FilterExpression
+	0,  // 22: anchamber.genetics.GenerateTankLabelsRequest.format:type_name -> anchamber.genetics.LabelFormat
+	1,  // 23: anchamber.genetics.GenerateTankLabelsRequest.symbology:type_name -> anchamber.genetics.Symbology
+	32, // 24: anchamber.genetics.ResolveScanRequest.readMask:type_name -> google.protobuf.FieldMask
+	2,  // 25: anchamber.genetics.ScanActionRequest.action:type_name -> anchamber.genetics.TankAction
+	4,  // 26: anchamber.genetics.TankService.StreamTanks:input_type -> anchamber.genetics.StreamTanksRequest
+	5,  // 27: anchamber.genetics.TankService.CountTanks:input_type -> anchamber.genetics.CountTanksRequest
+	7,  // 28: anchamber.genetics.TankService.SearchTanks:input_type -> anchamber.genetics.SearchTanksRequest
+	16, // 29: anchamber.genetics.TankService.GetTank:input_type -> anchamber.genetics.GetTankRequest
+	20, // 30: anchamber.genetics.TankService.CreateTank:input_type -> anchamber.genetics.CreateTankRequest
+	22, // 31: anchamber.genetics.TankService.UpdateTank:input_type -> anchamber.genetics.UpdateTankRequest
+	24, // 32: anchamber.genetics.TankService.DeleteTank:input_type -> anchamber.genetics.DeleteTankRequest
+	26, // 33: anchamber.genetics.TankService.GenerateTankLabels:input_type -> anchamber.genetics.GenerateTankLabelsRequest
+	28, // 34: anchamber.genetics.TankService.ResolveScan:input_type -> anchamber.genetics.ResolveScanRequest
+	29, // 35: anchamber.genetics.TankService.ScanAction:input_type -> anchamber.genetics.ScanActionRequest
+	17, // 36: anchamber.genetics.TankService.StreamTanks:output_type -> anchamber.genetics.TankResponse
+	6,  // 37: anchamber.genetics.TankService.CountTanks:output_type -> anchamber.genetics.CountTanksResponse
+	8,  // 38: anchamber.genetics.TankService.SearchTanks:output_type -> anchamber.genetics.SearchTanksResponse
+	17, // 39: anchamber.genetics.TankService.GetTank:output_type -> anchamber.genetics.TankResponse
+	21, // 40: anchamber.genetics.TankService.CreateTank:output_type -> anchamber.genetics.CreateTankResponse
+	23, // 41: anchamber.genetics.TankService.UpdateTank:output_type -> anchamber.genetics.UpdateTankResponse
+	25, // 42: anchamber.genetics.TankService.DeleteTank:output_type -> anchamber.genetics.DeleteTankResponse
+	27, // 43: anchamber.genetics.TankService.GenerateTankLabels:output_type -> anchamber.genetics.GenerateTankLabelsResponse
+	17, // 44: anchamber.genetics.TankService.ResolveScan:output_type -> anchamber.genetics.TankResponse
+	17, // 45: anchamber.genetics.TankService.ScanAction:output_type -> anchamber.genetics.TankResponse
+	36, // [36:46] is the sub-list for method output_type
+	26, // [26:36] is the sub-list for method input_type
+	26, // [26:26] is the sub-list for extension type_name
+	26, // [26:26] is the sub-list for extension extendee
+	0,  // [0:26] is the sub-list for field type_name
 }
 
 func init() { file_tank_proto_init() }
@@ -886,7 +2421,7 @@ func file_tank_proto_init() {
 			}
 		}
 		file_tank_proto_msgTypes[2].Exporter = func(v interface{}, i int) interface{} {
-			switch v := v.(*GetTankRequest); i {
+			switch v := v.(*CountTanksRequest); i {
 			case 0:
 				return &v.state
 			case 1:
@@ -898,7 +2433,7 @@ func file_tank_proto_init() {
 			}
 		}
 		file_tank_proto_msgTypes[3].Exporter = func(v interface{}, i int) interface{} {
-			switch v := v.(*TankResponse); i {
+			switch v := v.(*CountTanksResponse); i {
 			case 0:
 				return &v.state
 			case 1:
@@ -910,7 +2445,7 @@ func file_tank_proto_init() {
 			}
 		}
 		file_tank_proto_msgTypes[4].Exporter = func(v interface{}, i int) interface{} {
-			switch v := v.(*GetTankStatsRequest); i {
+			switch v := v.(*SearchTanksRequest); i {
 			case 0:
 				return &v.state
 			case 1:
@@ -922,7 +2457,7 @@ func file_tank_proto_init() {
 			}
 		}
 		file_tank_proto_msgTypes[5].Exporter = func(v interface{}, i int) interface{} {
-			switch v := v.(*GetTankStatsResponse); i {
+			switch v := v.(*SearchTanksResponse); i {
 			case 0:
 				return &v.state
 			case 1:
@@ -934,7 +2469,7 @@ func file_tank_proto_init() {
 			}
 		}
 		file_tank_proto_msgTypes[6].Exporter = func(v interface{}, i int) interface{} {
-			switch v := v.(*CreateTankRequest); i {
+			switch v := v.(*SearchResult); i {
 			case 0:
 				return &v.state
 			case 1:
@@ -946,7 +2481,7 @@ func file_tank_proto_init() {
 			}
 		}
 		file_tank_proto_msgTypes[7].Exporter = func(v interface{}, i int) interface{} {
-			switch v := v.(*CreateTankResponse); i {
+			switch v := v.(*OrderBy); i {
 			case 0:
 				return &v.state
 			case 1:
@@ -958,7 +2493,7 @@ func file_tank_proto_init() {
 			}
 		}
 		file_tank_proto_msgTypes[8].Exporter = func(v interface{}, i int) interface{} {
-			switch v := v.(*UpdateTankRequest); i {
+			switch v := v.(*FilterExpression); i {
 			case 0:
 				return &v.state
 			case 1:
@@ -970,7 +2505,7 @@ func file_tank_proto_init() {
 			}
 		}
 		file_tank_proto_msgTypes[9].Exporter = func(v interface{}, i int) interface{} {
-			switch v := v.(*UpdateTankResponse); i {
+			switch v := v.(*FilterExpressionList); i {
 			case 0:
 				return &v.state
 			case 1:
@@ -982,7 +2517,7 @@ func file_tank_proto_init() {
 			}
 		}
 		file_tank_proto_msgTypes[10].Exporter = func(v interface{}, i int) interface{} {
-			switch v := v.(*DeleteTankRequest); i {
+			switch v := v.(*InFilter); i {
 			case 0:
 				return &v.state
 			case 1:
@@ -994,6 +2529,138 @@ func file_tank_proto_init() {
 			}
 		}
 		file_tank_proto_msgTypes[11].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*BetweenFilter); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_tank_proto_msgTypes[12].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*IsNullFilter); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_tank_proto_msgTypes[13].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*GetTankRequest); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_tank_proto_msgTypes[14].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*TankResponse); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_tank_proto_msgTypes[15].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*GetTankStatsRequest); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_tank_proto_msgTypes[16].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*GetTankStatsResponse); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_tank_proto_msgTypes[17].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*CreateTankRequest); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_tank_proto_msgTypes[18].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*CreateTankResponse); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_tank_proto_msgTypes[19].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*UpdateTankRequest); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_tank_proto_msgTypes[20].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*UpdateTankResponse); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_tank_proto_msgTypes[21].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*DeleteTankRequest); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_tank_proto_msgTypes[22].Exporter = func(v interface{}, i int) interface{} {
 			switch v := v.(*DeleteTankResponse); i {
 			case 0:
 				return &v.state
@@ -1005,19 +2672,77 @@ func file_tank_proto_init() {
 				return nil
 			}
 		}
+		file_tank_proto_msgTypes[23].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*GenerateTankLabelsRequest); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_tank_proto_msgTypes[24].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*GenerateTankLabelsResponse); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_tank_proto_msgTypes[25].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*ResolveScanRequest); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_tank_proto_msgTypes[26].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*ScanActionRequest); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+	}
+	file_tank_proto_msgTypes[8].OneofWrappers = []interface{}{
+		(*FilterExpression_And)(nil),
+		(*FilterExpression_Or)(nil),
+		(*FilterExpression_Not)(nil),
+		(*FilterExpression_Condition)(nil),
+		(*FilterExpression_In)(nil),
+		(*FilterExpression_Between)(nil),
+		(*FilterExpression_IsNull)(nil),
 	}
 	type x struct{}
 	out := protoimpl.TypeBuilder{
 		File: protoimpl.DescBuilder{
 			GoPackagePath: reflect.TypeOf(x{}).PkgPath(),
 			RawDescriptor: file_tank_proto_rawDesc,
-			NumEnums:      0,
-			NumMessages:   12,
+			NumEnums:      3,
+			NumMessages:   27,
 			NumExtensions: 0,
 			NumServices:   1,
 		},
 		GoTypes:           file_tank_proto_goTypes,
 		DependencyIndexes: file_tank_proto_depIdxs,
+		EnumInfos:         file_tank_proto_enumTypes,
 		MessageInfos:      file_tank_proto_msgTypes,
 	}.Build()
 	File_tank_proto = out.File
@@ -1,4 +1,8 @@
 // Code generated by protoc-gen-go-grpc. DO NOT EDIT.
+// versions:
+// - protoc-gen-go-grpc v1.2.0
+// - protoc             v3.14.0
+// source: tank.proto
 
 package proto
 
@@ -18,11 +22,25 @@ const _ = grpc.SupportPackageIsVersion7
 //
 // For semantics around ctx use and closing/ending streaming RPCs, please refer to https://pkg.go.dev/google.golang.org/grpc/?tab=doc#ClientConn.NewStream.
 type TankServiceClient interface {
+	// StreamTanks sends the total number of matching tanks in the
+	// x-total-count header and the x-next-offset or x-next-page-token and
+	// x-query-time trailers.
 	StreamTanks(ctx context.Context, in *StreamTanksRequest, opts ...grpc.CallOption) (TankService_StreamTanksClient, error)
+	CountTanks(ctx context.Context, in *CountTanksRequest, opts ...grpc.CallOption) (*CountTanksResponse, error)
+	// SearchTanks finds tanks by words in their system, line, notes and
+	// responsible person, best matches first.
+	SearchTanks(ctx context.Context, in *SearchTanksRequest, opts ...grpc.CallOption) (*SearchTanksResponse, error)
 	GetTank(ctx context.Context, in *GetTankRequest, opts ...grpc.CallOption) (*TankResponse, error)
 	CreateTank(ctx context.Context, in *CreateTankRequest, opts ...grpc.CallOption) (*CreateTankResponse, error)
 	UpdateTank(ctx context.Context, in *UpdateTankRequest, opts ...grpc.CallOption) (*UpdateTankResponse, error)
 	DeleteTank(ctx context.Context, in *DeleteTankRequest, opts ...grpc.CallOption) (*DeleteTankResponse, error)
+	// GenerateTankLabels renders printable labels for the selected tanks.
+	GenerateTankLabels(ctx context.Context, in *GenerateTankLabelsRequest, opts ...grpc.CallOption) (*GenerateTankLabelsResponse, error)
+	// ResolveScan returns the tank of a scanned label.
+	ResolveScan(ctx context.Context, in *ResolveScanRequest, opts ...grpc.CallOption) (*TankResponse, error)
+	// ScanAction records an action on the tank of a scanned label and returns
+	// the updated tank.
+	ScanAction(ctx context.Context, in *ScanActionRequest, opts ...grpc.CallOption) (*TankResponse, error)
 }
 
 type tankServiceClient struct {
@@ -65,6 +83,24 @@ func (x *tankServiceStreamTanksClient) Recv() (*TankResponse, error) {
 	return m, nil
 }
 
+func (c *tankServiceClient) CountTanks(ctx context.Context, in *CountTanksRequest, opts ...grpc.CallOption) (*CountTanksResponse, error) {
+	out := new(CountTanksResponse)
+	err := c.cc.Invoke(ctx, "/anchamber.genetics.TankService/CountTanks", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *tankServiceClient) SearchTanks(ctx context.Context, in *SearchTanksRequest, opts ...grpc.CallOption) (*SearchTanksResponse, error) {
+	out := new(SearchTanksResponse)
+	err := c.cc.Invoke(ctx, "/anchamber.genetics.TankService/SearchTanks", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
 func (c *tankServiceClient) GetTank(ctx context.Context, in *GetTankRequest, opts ...grpc.CallOption) (*TankResponse, error) {
 	out := new(TankResponse)
 	err := c.cc.Invoke(ctx, "/anchamber.genetics.TankService/GetTank", in, out, opts...)
@@ -101,15 +137,56 @@ func (c *tankServiceClient) DeleteTank(ctx context.Context, in *DeleteTankReques
 	return out, nil
 }
 
+func (c *tankServiceClient) GenerateTankLabels(ctx context.Context, in *GenerateTankLabelsRequest, opts ...grpc.CallOption) (*GenerateTankLabelsResponse, error) {
+	out := new(GenerateTankLabelsResponse)
+	err := c.cc.Invoke(ctx, "/anchamber.genetics.TankService/GenerateTankLabels", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *tankServiceClient) ResolveScan(ctx context.Context, in *ResolveScanRequest, opts ...grpc.CallOption) (*TankResponse, error) {
+	out := new(TankResponse)
+	err := c.cc.Invoke(ctx, "/anchamber.genetics.TankService/ResolveScan", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *tankServiceClient) ScanAction(ctx context.Context, in *ScanActionRequest, opts ...grpc.CallOption) (*TankResponse, error) {
+	out := new(TankResponse)
+	err := c.cc.Invoke(ctx, "/anchamber.genetics.TankService/ScanAction", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
 // TankServiceServer is the server API for TankService service.
 // All implementations must embed UnimplementedTankServiceServer
 // for forward compatibility
 type TankServiceServer interface {
+	// StreamTanks sends the total number of matching tanks in the
+	// x-total-count header and the x-next-offset or x-next-page-token and
+	// x-query-time trailers.
 	StreamTanks(*StreamTanksRequest, TankService_StreamTanksServer) error
+	CountTanks(context.Context, *CountTanksRequest) (*CountTanksResponse, error)
+	// SearchTanks finds tanks by words in their system, line, notes and
+	// responsible person, best matches first.
+	SearchTanks(context.Context, *SearchTanksRequest) (*SearchTanksResponse, error)
 	GetTank(context.Context, *GetTankRequest) (*TankResponse, error)
 	CreateTank(context.Context, *CreateTankRequest) (*CreateTankResponse, error)
 	UpdateTank(context.Context, *UpdateTankRequest) (*UpdateTankResponse, error)
 	DeleteTank(context.Context, *DeleteTankRequest) (*DeleteTankResponse, error)
+	// GenerateTankLabels renders printable labels for the selected tanks.
+	GenerateTankLabels(context.Context, *GenerateTankLabelsRequest) (*GenerateTankLabelsResponse, error)
+	// ResolveScan returns the tank of a scanned label.
+	ResolveScan(context.Context, *ResolveScanRequest) (*TankResponse, error)
+	// ScanAction records an action on the tank of a scanned label and returns
+	// the updated tank.
+	ScanAction(context.Context, *ScanActionRequest) (*TankResponse, error)
 	mustEmbedUnimplementedTankServiceServer()
 }
 
@@ -120,6 +197,12 @@ type UnimplementedTankServiceServer struct {
 func (UnimplementedTankServiceServer) StreamTanks(*StreamTanksRequest, TankService_StreamTanksServer) error {
 	return status.Errorf(codes.Unimplemented, "method StreamTanks not implemented")
 }
+func (UnimplementedTankServiceServer) CountTanks(context.Context, *CountTanksRequest) (*CountTanksResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method CountTanks not implemented")
+}
+func (UnimplementedTankServiceServer) SearchTanks(context.Context, *SearchTanksRequest) (*SearchTanksResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method SearchTanks not implemented")
+}
 func (UnimplementedTankServiceServer) GetTank(context.Context, *GetTankRequest) (*TankResponse, error) {
 	return nil, status.Errorf(codes.Unimplemented, "method GetTank not implemented")
 }
@@ -132,6 +215,15 @@ func (UnimplementedTankServiceServer) UpdateTank(context.Context, *UpdateTankReq
 func (UnimplementedTankServiceServer) DeleteTank(context.Context, *DeleteTankRequest) (*DeleteTankResponse, error) {
 	return nil, status.Errorf(codes.Unimplemented, "method DeleteTank not implemented")
 }
+func (UnimplementedTankServiceServer) GenerateTankLabels(context.Context, *GenerateTankLabelsRequest) (*GenerateTankLabelsResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method GenerateTankLabels not implemented")
+}
+func (UnimplementedTankServiceServer) ResolveScan(context.Context, *ResolveScanRequest) (*TankResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method ResolveScan not implemented")
+}
+func (UnimplementedTankServiceServer) ScanAction(context.Context, *ScanActionRequest) (*TankResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method ScanAction not implemented")
+}
 func (UnimplementedTankServiceServer) mustEmbedUnimplementedTankServiceServer() {}
 
 // UnsafeTankServiceServer may be embedded to opt out of forward compatibility for this service.
@@ -166,6 +258,42 @@ func (x *tankServiceStreamTanksServer) Send(m *TankResponse) error {
 	return x.ServerStream.SendMsg(m)
 }
 
+func _TankService_CountTanks_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(CountTanksRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(TankServiceServer).CountTanks(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/anchamber.genetics.TankService/CountTanks",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(TankServiceServer).CountTanks(ctx, req.(*CountTanksRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _TankService_SearchTanks_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(SearchTanksRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(TankServiceServer).SearchTanks(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/anchamber.genetics.TankService/SearchTanks",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(TankServiceServer).SearchTanks(ctx, req.(*SearchTanksRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
 func _TankService_GetTank_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
 	in := new(GetTankRequest)
 	if err := dec(in); err != nil {
@@ -238,6 +366,60 @@ func _TankService_DeleteTank_Handler(srv interface{}, ctx context.Context, dec f
 	return interceptor(ctx, in, info, handler)
 }
 
+func _TankService_GenerateTankLabels_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(GenerateTankLabelsRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(TankServiceServer).GenerateTankLabels(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/anchamber.genetics.TankService/GenerateTankLabels",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(TankServiceServer).GenerateTankLabels(ctx, req.(*GenerateTankLabelsRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _TankService_ResolveScan_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(ResolveScanRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(TankServiceServer).ResolveScan(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/anchamber.genetics.TankService/ResolveScan",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(TankServiceServer).ResolveScan(ctx, req.(*ResolveScanRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _TankService_ScanAction_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(ScanActionRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(TankServiceServer).ScanAction(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/anchamber.genetics.TankService/ScanAction",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(TankServiceServer).ScanAction(ctx, req.(*ScanActionRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
 // TankService_ServiceDesc is the grpc.ServiceDesc for TankService service.
 // It's only intended for direct use with grpc.RegisterService,
 // and not to be introspected or modified (even as a copy)
@@ -245,6 +427,14 @@ var TankService_ServiceDesc = grpc.ServiceDesc{
 	ServiceName: "anchamber.genetics.TankService",
 	HandlerType: (*TankServiceServer)(nil),
 	Methods: []grpc.MethodDesc{
+		{
+			MethodName: "CountTanks",
+			Handler:    _TankService_CountTanks_Handler,
+		},
+		{
+			MethodName: "SearchTanks",
+			Handler:    _TankService_SearchTanks_Handler,
+		},
 		{
 			MethodName: "GetTank",
 			Handler:    _TankService_GetTank_Handler,
@@ -261,6 +451,18 @@ var TankService_ServiceDesc = grpc.ServiceDesc{
 			MethodName: "DeleteTank",
 			Handler:    _TankService_DeleteTank_Handler,
 		},
+		{
+			MethodName: "GenerateTankLabels",
+			Handler:    _TankService_GenerateTankLabels_Handler,
+		},
+		{
+			MethodName: "ResolveScan",
+			Handler:    _TankService_ResolveScan_Handler,
+		},
+		{
+			MethodName: "ScanAction",
+			Handler:    _TankService_ScanAction_Handler,
+		},
 	},
 	Streams: []grpc.StreamDesc{
 		{
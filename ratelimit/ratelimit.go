@@ -0,0 +1,208 @@
+package ratelimit
+
+import (
+	"context"
+	"math"
+	"net"
+	"strconv"
+	"sync"
+	"time"
+
+	"github.com/anchamber/genetics-tank/auth"
+	"google.golang.org/genproto/googleapis/rpc/errdetails"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/metadata"
+	"google.golang.org/grpc/peer"
+	"google.golang.org/grpc/status"
+	"google.golang.org/protobuf/types/known/durationpb"
+)
+
+// RetryAfterTrailer is the trailer telling a rejected client after how many
+// seconds it should try again.
+const RetryAfterTrailer = "retry-after"
+
+// streamRetryAfter is suggested when a stream is rejected, as it is unknown
+// when the other streams will finish.
+const streamRetryAfter = time.Second
+
+// idleTimeout is how long the bucket of a client is kept after it was full.
+const idleTimeout = 10 * time.Minute
+
+type Options struct {
+	// Rate is the number of requests per second a client can make on
+	// average. Requests are not limited if it is 0.
+	Rate float64
+	// Burst is the number of requests a client can make at once.
+	Burst int
+	// MaxStreams limits the concurrent streams of all clients. Streams are
+	// not limited if it is 0.
+	MaxStreams int
+	// MaxStreamsPerClient limits the concurrent streams of one client.
+	// Streams of a client are not limited if it is 0.
+	MaxStreamsPerClient int
+}
+
+// Limiter rejects requests of clients exceeding their rate and streams
+// exceeding the concurrency limits with ResourceExhausted.
+// Clients are identified by their authenticated principal or, if there is
+// none, by their peer address, so the interceptors have to run after the
+// authentication. Public methods are never limited.
+type Limiter struct {
+	options Options
+
+	mu        sync.Mutex
+	buckets   map[string]*bucket
+	streams   map[string]int
+	total     int
+	lastPrune time.Time
+}
+
+// bucket holds the tokens of a client. A request takes one token, tokens are
+// refilled at the rate of the limiter up to the burst.
+type bucket struct {
+	tokens float64
+	last   time.Time
+}
+
+func New(options Options) *Limiter {
+	return &Limiter{
+		options: options,
+		buckets: make(map[string]*bucket),
+		streams: make(map[string]int),
+	}
+}
+
+// SetOptions changes the limits. Streams that are already open are not
+// affected by lower stream limits.
+func (l *Limiter) SetOptions(options Options) {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+	l.options = options
+}
+
+func (l *Limiter) UnaryInterceptor(ctx context.Context, req interface{}, info *grpc.UnaryServerInfo, handler grpc.UnaryHandler) (interface{}, error) {
+	if auth.PublicMethods[info.FullMethod] {
+		return handler(ctx, req)
+	}
+	if retryAfter, ok := l.allow(clientKey(ctx)); !ok {
+		_ = grpc.SetTrailer(ctx, retryAfterTrailer(retryAfter))
+		return nil, exhausted("rate limit exceeded", retryAfter)
+	}
+	return handler(ctx, req)
+}
+
+func (l *Limiter) StreamInterceptor(srv interface{}, ss grpc.ServerStream, info *grpc.StreamServerInfo, handler grpc.StreamHandler) error {
+	if auth.PublicMethods[info.FullMethod] {
+		return handler(srv, ss)
+	}
+	key := clientKey(ss.Context())
+	if retryAfter, ok := l.allow(key); !ok {
+		ss.SetTrailer(retryAfterTrailer(retryAfter))
+		return exhausted("rate limit exceeded", retryAfter)
+	}
+	if !l.acquireStream(key) {
+		ss.SetTrailer(retryAfterTrailer(streamRetryAfter))
+		return exhausted("too many concurrent streams", streamRetryAfter)
+	}
+	defer l.releaseStream(key)
+	return handler(srv, ss)
+}
+
+// allow takes a token from the bucket of key. If there is none, it returns
+// the time until the next token is available.
+func (l *Limiter) allow(key string) (time.Duration, bool) {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+	if l.options.Rate <= 0 {
+		return 0, true
+	}
+	now := time.Now()
+	l.prune(now)
+	burst := math.Max(float64(l.options.Burst), 1)
+	b, ok := l.buckets[key]
+	if !ok {
+		b = &bucket{tokens: burst, last: now}
+		l.buckets[key] = b
+	}
+	b.tokens = math.Min(burst, b.tokens+now.Sub(b.last).Seconds()*l.options.Rate)
+	b.last = now
+	if b.tokens < 1 {
+		return time.Duration((1 - b.tokens) / l.options.Rate * float64(time.Second)), false
+	}
+	b.tokens--
+	return 0, true
+}
+
+// prune drops the buckets of clients that have been idle long enough for
+// their bucket to be full.
+func (l *Limiter) prune(now time.Time) {
+	if now.Sub(l.lastPrune) < idleTimeout {
+		return
+	}
+	l.lastPrune = now
+	for key, b := range l.buckets {
+		if now.Sub(b.last) > idleTimeout {
+			delete(l.buckets, key)
+		}
+	}
+}
+
+func (l *Limiter) acquireStream(key string) bool {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+	if l.options.MaxStreams > 0 && l.total >= l.options.MaxStreams {
+		return false
+	}
+	if l.options.MaxStreamsPerClient > 0 && l.streams[key] >= l.options.MaxStreamsPerClient {
+		return false
+	}
+	l.total++
+	l.streams[key]++
+	return true
+}
+
+func (l *Limiter) releaseStream(key string) {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+	l.total--
+	if l.streams[key] <= 1 {
+		delete(l.streams, key)
+		return
+	}
+	l.streams[key]--
+}
+
+// clientKey identifies the client of a request by its principal or, for
+// unauthenticated requests, by the host of its peer address.
+func clientKey(ctx context.Context) string {
+	if principal, ok := auth.FromContext(ctx); ok && principal.Subject != "" {
+		return "principal:" + principal.Subject
+	}
+	if p, ok := peer.FromContext(ctx); ok && p.Addr != nil {
+		host, _, err := net.SplitHostPort(p.Addr.String())
+		if err != nil {
+			host = p.Addr.String()
+		}
+		return "peer:" + host
+	}
+	return "unknown"
+}
+
+// retryAfterTrailer contains the seconds to wait, rounded up.
+func retryAfterTrailer(retryAfter time.Duration) metadata.MD {
+	seconds := int64(math.Ceil(retryAfter.Seconds()))
+	if seconds < 1 {
+		seconds = 1
+	}
+	return metadata.Pairs(RetryAfterTrailer, strconv.FormatInt(seconds, 10))
+}
+
+func exhausted(message string, retryAfter time.Duration) error {
+	st := status.New(codes.ResourceExhausted, message)
+	withDetails, err := st.WithDetails(&errdetails.RetryInfo{RetryDelay: durationpb.New(retryAfter)})
+	if err != nil {
+		return st.Err()
+	}
+	return withDetails.Err()
+}
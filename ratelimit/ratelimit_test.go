@@ -0,0 +1,131 @@
+package ratelimit_test
+
+import (
+	"context"
+	"net"
+	"testing"
+
+	"github.com/anchamber/genetics-tank/auth"
+	"github.com/anchamber/genetics-tank/ratelimit"
+	"google.golang.org/genproto/googleapis/rpc/errdetails"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/metadata"
+	"google.golang.org/grpc/peer"
+	"google.golang.org/grpc/status"
+)
+
+const method = "/anchamber.genetics.TankService/StreamTanks"
+
+type mockStream struct {
+	grpc.ServerStream
+	ctx     context.Context
+	trailer metadata.MD
+}
+
+func (m *mockStream) Context() context.Context {
+	return m.ctx
+}
+
+func (m *mockStream) SetTrailer(md metadata.MD) {
+	m.trailer = metadata.Join(m.trailer, md)
+}
+
+func principalContext(subject string) context.Context {
+	return auth.NewContext(context.Background(), &auth.Principal{Subject: subject})
+}
+
+func TestRateLimit(t *testing.T) {
+	limiter := ratelimit.New(ratelimit.Options{Rate: 0.001, Burst: 2})
+	info := &grpc.UnaryServerInfo{FullMethod: method}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return "ok", nil
+	}
+	for i := 0; i < 2; i++ {
+		if _, err := limiter.UnaryInterceptor(principalContext("alice"), nil, info, handler); err != nil {
+			t.Fatalf("request %d within burst was rejected: %v", i, err)
+		}
+	}
+	_, err := limiter.UnaryInterceptor(principalContext("alice"), nil, info, handler)
+	if status.Code(err) != codes.ResourceExhausted {
+		t.Fatalf("expected ResourceExhausted after the burst, got %v", err)
+	}
+	var retryInfo *errdetails.RetryInfo
+	for _, detail := range status.Convert(err).Details() {
+		if info, ok := detail.(*errdetails.RetryInfo); ok {
+			retryInfo = info
+		}
+	}
+	if retryInfo == nil || retryInfo.RetryDelay.AsDuration() <= 0 {
+		t.Errorf("expected a retry delay, got %v", retryInfo)
+	}
+
+	if _, err := limiter.UnaryInterceptor(principalContext("bob"), nil, info, handler); err != nil {
+		t.Errorf("other principal was rejected: %v", err)
+	}
+	peerCtx := peer.NewContext(context.Background(), &peer.Peer{Addr: &net.TCPAddr{IP: net.IPv4(10, 0, 0, 1), Port: 1234}})
+	if _, err := limiter.UnaryInterceptor(peerCtx, nil, info, handler); err != nil {
+		t.Errorf("unauthenticated peer was rejected: %v", err)
+	}
+	health := &grpc.UnaryServerInfo{FullMethod: "/grpc.health.v1.Health/Check"}
+	for i := 0; i < 3; i++ {
+		if _, err := limiter.UnaryInterceptor(principalContext("alice"), nil, health, handler); err != nil {
+			t.Errorf("public method was rejected: %v", err)
+		}
+	}
+}
+
+func TestStreamLimits(t *testing.T) {
+	limiter := ratelimit.New(ratelimit.Options{Rate: 0.001, Burst: 2, MaxStreams: 2, MaxStreamsPerClient: 1})
+	info := &grpc.StreamServerInfo{FullMethod: method, IsServerStream: true}
+
+	release := make(chan struct{})
+	done := make(chan error)
+	openStream := func(subject string) {
+		started := make(chan struct{})
+		go func() {
+			done <- limiter.StreamInterceptor(nil, &mockStream{ctx: principalContext(subject)}, info, func(srv interface{}, stream grpc.ServerStream) error {
+				close(started)
+				<-release
+				return nil
+			})
+		}()
+		<-started
+	}
+	openStream("alice")
+
+	testCases := []struct {
+		name      string
+		subject   string
+		open      string
+		errorCode codes.Code
+	}{
+		{name: "second stream of a client", subject: "alice", errorCode: codes.ResourceExhausted},
+		{name: "stream of another client", subject: "bob", errorCode: codes.OK},
+		{name: "another stream of the client after the first finished", subject: "bob", errorCode: codes.OK},
+		{name: "rate limit exceeded", subject: "bob", errorCode: codes.ResourceExhausted},
+		{name: "too many streams overall", subject: "dave", open: "carol", errorCode: codes.ResourceExhausted},
+	}
+	for _, tc := range testCases {
+		if tc.open != "" {
+			openStream(tc.open)
+		}
+		stream := &mockStream{ctx: principalContext(tc.subject)}
+		err := limiter.StreamInterceptor(nil, stream, info, func(srv interface{}, stream grpc.ServerStream) error {
+			return nil
+		})
+		if status.Code(err) != tc.errorCode {
+			t.Errorf("%s: expected %s, got %v", tc.name, tc.errorCode, err)
+		}
+		if tc.errorCode == codes.ResourceExhausted && len(stream.trailer.Get(ratelimit.RetryAfterTrailer)) == 0 {
+			t.Errorf("%s: expected %s trailer", tc.name, ratelimit.RetryAfterTrailer)
+		}
+	}
+
+	close(release)
+	for i := 0; i < 2; i++ {
+		if err := <-done; err != nil {
+			t.Errorf("blocking stream failed: %v", err)
+		}
+	}
+}
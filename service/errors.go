@@ -0,0 +1,93 @@
+package service
+
+import (
+	"context"
+	"errors"
+	"fmt"
+
+	"github.com/anchamber/genetics-tank/db"
+	"google.golang.org/genproto/googleapis/rpc/errdetails"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+	"google.golang.org/protobuf/runtime/protoiface"
+)
+
+const tankResourceType = "anchamber.genetics.Tank"
+
+// errorStatus converts an error of the database or stream into a gRPC
+// status with details about the affected tank. Requests that were cancelled
+// or ran out of time keep that code, unknown errors are reported as internal
+// errors without exposing their cause.
+func errorStatus(ctx context.Context, err error) error {
+	var dbErr *db.Error
+	var number uint32
+	if errors.As(err, &dbErr) {
+		number = dbErr.Number
+	}
+	switch {
+	case errors.Is(err, context.Canceled) || errors.Is(ctx.Err(), context.Canceled):
+		return status.Error(codes.Canceled, "request cancelled")
+	case errors.Is(err, context.DeadlineExceeded) || errors.Is(ctx.Err(), context.DeadlineExceeded):
+		return status.Error(codes.DeadlineExceeded, "request deadline exceeded")
+	case errors.Is(err, db.ErrNotFound):
+		return tankNotFound(number)
+	case errors.Is(err, db.ErrConflict):
+		return withDetails(status.New(codes.AlreadyExists, fmt.Sprintf("tank with number %d already exists", number)),
+			tankResourceInfo(number, "tank already exists"))
+	case errors.Is(err, db.ErrConstraint):
+		return withDetails(status.New(codes.FailedPrecondition, "tank violates a constraint of the database"),
+			&errdetails.PreconditionFailure{Violations: []*errdetails.PreconditionFailure_Violation{{
+				Type:        "CONSTRAINT",
+				Subject:     tankResourceName(number),
+				Description: "tank violates a constraint of the database",
+			}}})
+	case errors.Is(err, db.ErrPrecondition):
+		return withDetails(status.New(codes.FailedPrecondition, "tank does not meet the precondition of the change"),
+			&errdetails.PreconditionFailure{Violations: []*errdetails.PreconditionFailure_Violation{{
+				Type:        "STATE",
+				Subject:     tankResourceName(number),
+				Description: "tank does not meet the precondition of the change",
+			}}})
+	case errors.Is(err, db.ErrInvalidOptions):
+		return status.Error(codes.InvalidArgument, "invalid query options")
+	case errors.Is(err, db.ErrUnsupported):
+		return status.Error(codes.Unimplemented, "operation not supported by the database")
+	case errors.Is(err, db.ErrUnavailable):
+		return status.Error(codes.Unavailable, "database unavailable")
+	default:
+		return status.Error(codes.Internal, "internal server error")
+	}
+}
+
+// tankNotFound is returned for tanks that do not exist as well as for tanks
+// outside the scope of the caller, so that both can't be told apart.
+func tankNotFound(number uint32) error {
+	return withDetails(status.New(codes.NotFound, fmt.Sprintf("no tank with number %d found", number)),
+		tankResourceInfo(number, "tank not found"))
+}
+
+// invalidArgument reports the fields of a request that are invalid.
+func invalidArgument(violations ...*errdetails.BadRequest_FieldViolation) error {
+	return withDetails(status.New(codes.InvalidArgument, "request contains invalid fields"),
+		&errdetails.BadRequest{FieldViolations: violations})
+}
+
+func tankResourceInfo(number uint32, description string) *errdetails.ResourceInfo {
+	return &errdetails.ResourceInfo{
+		ResourceType: tankResourceType,
+		ResourceName: tankResourceName(number),
+		Description:  description,
+	}
+}
+
+func tankResourceName(number uint32) string {
+	return fmt.Sprintf("tanks/%d", number)
+}
+
+func withDetails(st *status.Status, details ...protoiface.MessageV1) error {
+	detailed, err := st.WithDetails(details...)
+	if err != nil {
+		return st.Err()
+	}
+	return detailed.Err()
+}
@@ -0,0 +1,145 @@
+package service
+
+import (
+	"fmt"
+
+	apiModel "github.com/anchamber/genetics-api/model"
+	apiProto "github.com/anchamber/genetics-api/proto"
+	"github.com/anchamber/genetics-tank/db"
+	pb "github.com/anchamber/genetics-tank/proto"
+	"google.golang.org/genproto/googleapis/rpc/errdetails"
+)
+
+// parseFilters converts the filters and filter expression of a request into
+// one expression. Unknown keys, operators the key does not support and values
+// that can't be converted to the type of the key are reported as violations.
+func parseFilters(filters []*apiProto.Filter, filter *pb.FilterExpression) (db.Expression, []*errdetails.BadRequest_FieldViolation) {
+	var violations []*errdetails.BadRequest_FieldViolation
+	and := db.And{}
+	for i, f := range filters {
+		and = append(and, convertCondition(apiModel.NewFilterFromProto(f), fmt.Sprintf("filters[%d]", i), &violations))
+	}
+	if filter != nil {
+		and = append(and, convertExpression(filter, "filter", 0, &violations))
+	}
+	if len(and) == 0 {
+		return nil, violations
+	}
+	return and, violations
+}
+
+// maxFilterDepth limits the nesting of filter expressions.
+const maxFilterDepth = 16
+
+func convertExpression(expression *pb.FilterExpression, field string, depth int, violations *[]*errdetails.BadRequest_FieldViolation) db.Expression {
+	if depth >= maxFilterDepth {
+		violate(violations, field, fmt.Sprintf("filter must not be nested deeper than %d levels", maxFilterDepth))
+		return nil
+	}
+
+	switch e := expression.GetExpression().(type) {
+	case *pb.FilterExpression_And:
+		and := db.And{}
+		for i, child := range e.And.GetExpressions() {
+			and = append(and, convertExpression(child, fmt.Sprintf("%s.and.expressions[%d]", field, i), depth+1, violations))
+		}
+		return and
+	case *pb.FilterExpression_Or:
+		or := db.Or{}
+		for i, child := range e.Or.GetExpressions() {
+			or = append(or, convertExpression(child, fmt.Sprintf("%s.or.expressions[%d]", field, i), depth+1, violations))
+		}
+		return or
+	case *pb.FilterExpression_Not:
+		return db.Not{Expression: convertExpression(e.Not, field+".not", depth+1, violations)}
+	case *pb.FilterExpression_Condition:
+		return convertCondition(apiModel.NewFilterFromProto(e.Condition), field+".condition", violations)
+	case *pb.FilterExpression_In:
+		schemaField := lookupField(e.In.GetKey(), field+".in.key", violations)
+		values := make([]interface{}, len(e.In.GetValues()))
+		for i, value := range e.In.GetValues() {
+			values[i] = coerce(schemaField, value, fmt.Sprintf("%s.in.values[%d]", field, i), violations)
+		}
+		return db.In{Key: e.In.GetKey(), Values: values}
+	case *pb.FilterExpression_Between:
+		schemaField := lookupField(e.Between.GetKey(), field+".between.key", violations)
+		if schemaField != nil && !schemaField.Ordered() {
+			violate(violations, field+".between.key", fmt.Sprintf("%s is a %s and can not be compared with between", schemaField.Key, schemaField.Type))
+			schemaField = nil
+		}
+		return db.Between{
+			Key:   e.Between.GetKey(),
+			Lower: coerce(schemaField, e.Between.GetLower(), field+".between.lower", violations),
+			Upper: coerce(schemaField, e.Between.GetUpper(), field+".between.upper", violations),
+		}
+	case *pb.FilterExpression_IsNull:
+		lookupField(e.IsNull.GetKey(), field+".isNull.key", violations)
+		return db.IsNull{Key: e.IsNull.GetKey()}
+	default:
+		violate(violations, field, "filter expression must not be empty")
+		return nil
+	}
+}
+
+// convertCondition converts a single filter into a comparison of its column
+// with the coerced value.
+func convertCondition(filter *apiModel.Filter, field string, violations *[]*errdetails.BadRequest_FieldViolation) db.Expression {
+	schemaField := lookupField(filter.Key, field+".key", violations)
+	if schemaField == nil {
+		return nil
+	}
+	if !schemaField.Allows(filter.Operator) {
+		violate(violations, field+".operator", fmt.Sprintf("operator %s is not supported for %s %s", apiProto.Operator(filter.Operator), schemaField.Type, schemaField.Key))
+		return nil
+	}
+	return db.Comparison{
+		Key:      schemaField.Column,
+		Operator: filter.Operator,
+		Value:    coerce(schemaField, filter.Value, field+".value", violations),
+	}
+}
+
+// lookupField returns the schema field of key or reports it as unknown.
+func lookupField(key string, field string, violations *[]*errdetails.BadRequest_FieldViolation) *db.Field {
+	schemaField := db.TankSchema.Field(key)
+	if schemaField == nil {
+		violate(violations, field, fmt.Sprintf("unknown filter key %q", key))
+	}
+	return schemaField
+}
+
+// coerce converts value into the type of schemaField. If schemaField is nil,
+// the key was already reported and value is returned unchanged.
+func coerce(schemaField *db.Field, value string, field string, violations *[]*errdetails.BadRequest_FieldViolation) interface{} {
+	if schemaField == nil {
+		return value
+	}
+	coerced, err := schemaField.Coerce(value)
+	if err != nil {
+		violate(violations, field, fmt.Sprintf("invalid value for %s: %v", schemaField.Key, err))
+		return value
+	}
+	return coerced
+}
+
+func violate(violations *[]*errdetails.BadRequest_FieldViolation, field string, description string) {
+	*violations = append(*violations, &errdetails.BadRequest_FieldViolation{Field: field, Description: description})
+}
+
+// toOrders converts the sort keys of a request. Keys that can't be sorted by
+// are reported as violations of field.
+func toOrders(orderBy []*pb.OrderBy, field string) ([]db.Order, []*errdetails.BadRequest_FieldViolation) {
+	var orders []db.Order
+	var violations []*errdetails.BadRequest_FieldViolation
+	for i, order := range orderBy {
+		if !db.IsSortKey(order.GetKey()) {
+			violations = append(violations, &errdetails.BadRequest_FieldViolation{
+				Field:       fmt.Sprintf("%s[%d].key", field, i),
+				Description: fmt.Sprintf("can not sort by %q", order.GetKey()),
+			})
+			continue
+		}
+		orders = append(orders, db.Order{Key: order.GetKey(), Descending: order.GetDescending()})
+	}
+	return orders, violations
+}
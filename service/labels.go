@@ -0,0 +1,130 @@
+package service
+
+import (
+	"context"
+	"fmt"
+
+	apiModel "github.com/anchamber/genetics-api/model"
+	"github.com/anchamber/genetics-tank/db"
+	"github.com/anchamber/genetics-tank/label"
+	"github.com/anchamber/genetics-tank/logging"
+	pb "github.com/anchamber/genetics-tank/proto"
+	"go.opentelemetry.io/otel/attribute"
+	"google.golang.org/genproto/googleapis/rpc/errdetails"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+// maxLabels limits the number of labels rendered by one request.
+const maxLabels = 1000
+
+func (s *TankService) GenerateTankLabels(ctx context.Context, in *pb.GenerateTankLabelsRequest) (*pb.GenerateTankLabelsResponse, error) {
+	logger := logging.FromContext(ctx, s.logger)
+	logger.Info("generate tank labels", "numbers", len(in.Numbers), "filters", len(in.Filters), "format", in.Format.String())
+	expression, violations := parseFilters(in.Filters, in.Filter)
+	options, optionViolations := s.labelOptions(in)
+	violations = append(violations, optionViolations...)
+	if len(in.Numbers) > maxLabels {
+		violations = append(violations, &errdetails.BadRequest_FieldViolation{
+			Field:       "numbers",
+			Description: fmt.Sprintf("at most %d tanks can be labeled at once", maxLabels),
+		})
+	}
+	if len(violations) > 0 {
+		return nil, invalidArgument(violations...)
+	}
+
+	conditions := db.And{}
+	if expression != nil {
+		conditions = append(conditions, expression)
+	}
+	if len(in.Numbers) > 0 {
+		numbers := make([]interface{}, len(in.Numbers))
+		for i, number := range in.Numbers {
+			numbers[i] = number
+		}
+		conditions = append(conditions, db.In{Key: "number", Values: numbers})
+	}
+	dbCtx, span := startDBSpan(ctx, "Select", attribute.Int("filters.count", len(in.Filters)))
+	tanks, err := s.db.Select(dbCtx, db.Options{
+		Pageination: &apiModel.Pageination{Limit: maxLabels + 1},
+		Expression:  conditions,
+		Columns:     []string{"number", "system", "line"},
+		Scope:       scopeOf(ctx),
+	})
+	endSpan(span, err)
+	if err != nil {
+		logger.Error("failed to select tanks", "error", err)
+		return nil, errorStatus(ctx, err)
+	}
+	if len(tanks) > maxLabels {
+		return nil, invalidArgument(&errdetails.BadRequest_FieldViolation{
+			Field:       "filter",
+			Description: fmt.Sprintf("more than %d tanks match, at most %d can be labeled at once", maxLabels, maxLabels),
+		})
+	}
+
+	found := make(map[uint32]bool, len(tanks))
+	labels := make([]label.Label, len(tanks))
+	for i, tank := range tanks {
+		found[tank.Number] = true
+		labels[i] = label.Label{Number: tank.Number, System: tank.System, Line: tank.Line}
+	}
+	for _, number := range in.Numbers {
+		if !found[number] {
+			return nil, tankNotFound(number)
+		}
+	}
+
+	pages, err := label.Render(labels, options)
+	if err != nil {
+		logger.Error("failed to render labels", "error", err)
+		return nil, status.Error(codes.Internal, "failed to render labels")
+	}
+	logger.Info("generated tank labels", "labels", len(labels), "pages", len(pages))
+	return &pb.GenerateTankLabelsResponse{ContentType: options.Format.ContentType(), Pages: pages}, nil
+}
+
+// labelOptions converts the format, symbology and template of a request.
+func (s *TankService) labelOptions(in *pb.GenerateTankLabelsRequest) (label.Options, []*errdetails.BadRequest_FieldViolation) {
+	var options label.Options
+	var violations []*errdetails.BadRequest_FieldViolation
+	templateName := label.DefaultSheetTemplate
+	switch in.Format {
+	case pb.LabelFormat_SVG:
+		options.Format = label.SVG
+	case pb.LabelFormat_PNG:
+		options.Format = label.PNG
+	case pb.LabelFormat_ZPL:
+		options.Format = label.ZPL
+		templateName = label.DefaultThermalTemplate
+	default:
+		violations = append(violations, &errdetails.BadRequest_FieldViolation{
+			Field:       "format",
+			Description: fmt.Sprintf("unknown format %d", in.Format),
+		})
+	}
+	switch in.Symbology {
+	case pb.Symbology_CODE128:
+		options.Symbology = label.Code128
+	case pb.Symbology_QR:
+		options.Symbology = label.QR
+	default:
+		violations = append(violations, &errdetails.BadRequest_FieldViolation{
+			Field:       "symbology",
+			Description: fmt.Sprintf("unknown symbology %d", in.Symbology),
+		})
+	}
+	if in.Template != "" {
+		templateName = in.Template
+	}
+	template, ok := s.labelTemplates[templateName]
+	if !ok {
+		violations = append(violations, &errdetails.BadRequest_FieldViolation{
+			Field:       "template",
+			Description: fmt.Sprintf("unknown template %q", templateName),
+		})
+	}
+	options.Template = template
+	return options, violations
+}
@@ -0,0 +1,88 @@
+package service
+
+import (
+	"crypto/sha256"
+
+	apiModel "github.com/anchamber/genetics-api/model"
+	"github.com/anchamber/genetics-tank/db"
+	"github.com/anchamber/genetics-tank/db/model"
+	pb "github.com/anchamber/genetics-tank/proto"
+	"google.golang.org/genproto/googleapis/rpc/errdetails"
+	"google.golang.org/protobuf/proto"
+)
+
+// Metadata sent by StreamTanks.
+const (
+	// TotalCountHeader holds the number of tanks matching the filters.
+	TotalCountHeader = "x-total-count"
+	// NextOffsetTrailer holds the offset of the next page when paging with
+	// limit and offset.
+	NextOffsetTrailer = "x-next-offset"
+	// NextPageTokenTrailer holds the token of the next page when paging with
+	// page tokens.
+	NextPageTokenTrailer = "x-next-page-token"
+	// QueryTimeTrailer holds the time spent querying the database.
+	QueryTimeTrailer = "x-query-time"
+)
+
+const (
+	defaultPageSize = 100
+	maxPageSize     = 1000
+)
+
+// usesPageTokens reports whether in is paged with page tokens instead of
+// limit and offset.
+func usesPageTokens(in *pb.StreamTanksRequest) bool {
+	return in.PageToken != "" || in.PageSize > 0
+}
+
+// applyPageToken restricts options to the page requested by in and returns
+// the size of the page. One more tank than the page size is selected to find
+// out whether there is a next page.
+func (s *TankService) applyPageToken(in *pb.StreamTanksRequest, options *db.Options) (uint32, []*errdetails.BadRequest_FieldViolation) {
+	var violations []*errdetails.BadRequest_FieldViolation
+	if in.GetPageination().GetOffset() > 0 {
+		violations = append(violations, &errdetails.BadRequest_FieldViolation{
+			Field:       "pageination.offset",
+			Description: "offset can not be combined with page tokens",
+		})
+	}
+	pageSize := in.PageSize
+	switch {
+	case pageSize == 0:
+		pageSize = defaultPageSize
+	case pageSize > maxPageSize:
+		pageSize = maxPageSize
+	}
+	if in.PageToken != "" {
+		values, err := s.pageTokens.Decode(in.PageToken, queryHash(in))
+		if err != nil {
+			violations = append(violations, &errdetails.BadRequest_FieldViolation{
+				Field:       "pageToken",
+				Description: "page token is invalid or belongs to a request with other filters or order",
+			})
+		} else {
+			options.After = &db.Cursor{Values: values}
+		}
+	}
+	options.Pageination = &apiModel.Pageination{Limit: pageSize + 1}
+	return pageSize, violations
+}
+
+// nextPageToken returns the token of the page following last.
+func (s *TankService) nextPageToken(in *pb.StreamTanksRequest, options *db.Options, last *model.Tank) (string, error) {
+	return s.pageTokens.Encode(queryHash(in), options.CursorOf(last).Values)
+}
+
+// queryHash identifies the filters and order of a request, so a page token
+// can't be used with a different query.
+func queryHash(in *pb.StreamTanksRequest) []byte {
+	query := &pb.StreamTanksRequest{
+		Filters: in.Filters,
+		Filter:  in.Filter,
+		OrderBy: in.OrderBy,
+	}
+	data, _ := proto.MarshalOptions{Deterministic: true}.Marshal(query)
+	sum := sha256.Sum256(data)
+	return sum[:]
+}
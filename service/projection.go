@@ -0,0 +1,55 @@
+package service
+
+import (
+	"fmt"
+
+	pb "github.com/anchamber/genetics-tank/proto"
+	"github.com/mennanov/fmutils"
+	"google.golang.org/genproto/googleapis/rpc/errdetails"
+	"google.golang.org/protobuf/types/known/fieldmaskpb"
+)
+
+// responseColumns maps the fields of pb.TankResponse to the columns they are
+// read from.
+var responseColumns = map[string]string{
+	"id":          "id",
+	"system":      "system",
+	"number":      "number",
+	"active":      "active",
+	"size":        "size",
+	"fishCount":   "fish_count",
+	"lab":         "lab",
+	"line":        "line",
+	"notes":       "notes",
+	"responsible": "responsible",
+	"lastCleaned": "last_cleaned",
+	"lastFed":     "last_fed",
+}
+
+// toColumns returns the columns needed for the fields named in mask, or nil
+// for all columns if mask is empty. Unknown fields are reported as
+// violations of field.
+func toColumns(mask *fieldmaskpb.FieldMask, field string) ([]string, []*errdetails.BadRequest_FieldViolation) {
+	var columns []string
+	var violations []*errdetails.BadRequest_FieldViolation
+	for i, path := range mask.GetPaths() {
+		column, ok := responseColumns[path]
+		if !ok {
+			violations = append(violations, &errdetails.BadRequest_FieldViolation{
+				Field:       fmt.Sprintf("%s.paths[%d]", field, i),
+				Description: fmt.Sprintf("unknown tank field %q", path),
+			})
+			continue
+		}
+		columns = append(columns, column)
+	}
+	return columns, violations
+}
+
+// applyReadMask clears the fields of response not named in mask.
+func applyReadMask(response *pb.TankResponse, mask *fieldmaskpb.FieldMask) *pb.TankResponse {
+	if len(mask.GetPaths()) > 0 {
+		fmutils.Filter(response, mask.GetPaths())
+	}
+	return response
+}
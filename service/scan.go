@@ -0,0 +1,102 @@
+package service
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/anchamber/genetics-tank/db"
+	"github.com/anchamber/genetics-tank/label"
+	"github.com/anchamber/genetics-tank/logging"
+	pb "github.com/anchamber/genetics-tank/proto"
+	"go.opentelemetry.io/otel/attribute"
+	"google.golang.org/genproto/googleapis/rpc/errdetails"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+// ResolveScan returns the tank of a scanned label like GetTank.
+func (s *TankService) ResolveScan(ctx context.Context, in *pb.ResolveScanRequest) (*pb.TankResponse, error) {
+	number, err := label.ParsePayload(in.Payload)
+	if err != nil {
+		return nil, invalidArgument(payloadViolation(err))
+	}
+	logging.FromContext(ctx, s.logger).Info("resolve scan", "number", number)
+	return s.GetTank(ctx, &pb.GetTankRequest{Number: number, ReadMask: in.ReadMask})
+}
+
+// ScanAction applies the action to the tank of a scanned label. The change is
+// made atomically by the database, so concurrent scans of the same tank
+// don't overwrite each other.
+func (s *TankService) ScanAction(ctx context.Context, in *pb.ScanActionRequest) (*pb.TankResponse, error) {
+	logger := logging.FromContext(ctx, s.logger)
+	var violations []*errdetails.BadRequest_FieldViolation
+	number, err := label.ParsePayload(in.Payload)
+	if err != nil {
+		violations = append(violations, payloadViolation(err))
+	}
+	switch in.Action {
+	case pb.TankAction_CLEANED, pb.TankAction_FED:
+		if in.Count != 0 {
+			violate(&violations, "count", fmt.Sprintf("count is only used for %s", pb.TankAction_MORTALITY))
+		}
+	case pb.TankAction_MORTALITY:
+		if in.Count == 0 {
+			violate(&violations, "count", fmt.Sprintf("count must be at least 1 for %s", pb.TankAction_MORTALITY))
+		}
+	default:
+		violate(&violations, "action", fmt.Sprintf("unknown action %s", in.Action))
+	}
+	if len(violations) > 0 {
+		return nil, invalidArgument(violations...)
+	}
+	logger = logger.With("number", number)
+	logger.Info("scan action", "action", in.Action.String(), "count", in.Count)
+
+	scope := scopeOf(ctx)
+	dbCtx, span := startDBSpan(ctx, "SelectByNumber", attribute.Int64("tank.number", int64(number)))
+	tank, err := s.db.SelectByNumber(dbCtx, number, "system", "lab")
+	endSpan(span, err)
+	if errors.Is(err, db.ErrNotFound) {
+		return nil, tankNotFound(number)
+	}
+	if err != nil {
+		logger.Error("failed to select tank", "error", err)
+		return nil, errorStatus(ctx, err)
+	}
+	if !scope.Allows(tank) {
+		return nil, tankNotFound(number)
+	}
+
+	var change db.Change
+	switch in.Action {
+	case pb.TankAction_CLEANED:
+		change.LastCleaned = time.Now().Unix()
+	case pb.TankAction_FED:
+		change.LastFed = time.Now().Unix()
+	case pb.TankAction_MORTALITY:
+		change.Mortality = in.Count
+	}
+	dbCtx, span = startDBSpan(ctx, "Apply", attribute.Int64("tank.number", int64(number)))
+	tank, err = s.db.Apply(dbCtx, number, change)
+	endSpan(span, err)
+	if errors.Is(err, db.ErrPrecondition) {
+		description := fmt.Sprintf("tank %d has fewer than %d fish", number, in.Count)
+		return nil, withDetails(status.New(codes.FailedPrecondition, description),
+			&errdetails.PreconditionFailure{Violations: []*errdetails.PreconditionFailure_Violation{{
+				Type:        "FISH_COUNT",
+				Subject:     tankResourceName(number),
+				Description: description,
+			}}})
+	}
+	if err != nil {
+		logger.Error("failed to apply scan action", "error", err)
+		return nil, errorStatus(ctx, err)
+	}
+	return mapToResponse(tank), nil
+}
+
+func payloadViolation(err error) *errdetails.BadRequest_FieldViolation {
+	return &errdetails.BadRequest_FieldViolation{Field: "payload", Description: err.Error()}
+}
@@ -2,29 +2,48 @@ package service
 
 import (
 	"context"
+	"errors"
 	"fmt"
 	apiModel "github.com/anchamber/genetics-api/model"
+	"github.com/anchamber/genetics-tank/auth"
 	"github.com/anchamber/genetics-tank/db"
 	"github.com/anchamber/genetics-tank/db/model"
+	"github.com/anchamber/genetics-tank/label"
+	"github.com/anchamber/genetics-tank/logging"
+	"github.com/anchamber/genetics-tank/pagetoken"
 	pb "github.com/anchamber/genetics-tank/proto"
-	"github.com/mennanov/fmutils"
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/trace"
+	"google.golang.org/genproto/googleapis/rpc/errdetails"
 	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/metadata"
 	"google.golang.org/grpc/status"
-	"google.golang.org/protobuf/proto"
-	"log"
+	"google.golang.org/protobuf/reflect/protoreflect"
+	"log/slog"
+	"strconv"
+	"strings"
+	"time"
 )
 
 type TankService struct {
 	pb.UnimplementedTankServiceServer
-	db db.TankDB
+	db         db.TankDB
+	logger     *slog.Logger
+	pageTokens *pagetoken.Signer
+	// labelTemplates are the templates GenerateTankLabels can use by name.
+	labelTemplates map[string]label.Template
 }
 
-var filterKeys = []string{
-	"id", "name", "location", "type", "responsible", "cleaning_interval", "last_cleaned",
-}
+const (
+	defaultSearchLimit = 20
+	maxSearchLimit     = 100
+)
 
 func (s *TankService) StreamTanks(in *pb.StreamTanksRequest, stream pb.TankService_StreamTanksServer) error {
-	log.Printf("GET: received with %d filters\n", len(in.Filters))
+	ctx := stream.Context()
+	logger := logging.FromContext(ctx, s.logger)
+	logger.Info("stream tanks", "filters", len(in.Filters))
+	_, parseSpan := tracer.Start(ctx, "parse filters", trace.WithAttributes(attribute.Int("filters.count", len(in.Filters))))
 	var paginationSettings *apiModel.Pageination
 	if in.Pageination != nil {
 		paginationSettings = &apiModel.Pageination{
@@ -33,132 +52,364 @@ func (s *TankService) StreamTanks(in *pb.StreamTanksRequest, stream pb.TankServi
 		}
 	}
 
-	var filterSettings []*apiModel.Filter
-	if in.Filters != nil {
-		for _, filter := range in.Filters {
-			found := false
-			for _, fk := range filterKeys {
-				if fk == filter.Key {
-					filterSettings = append(filterSettings, apiModel.NewFilterFromProto(filter))
-					found = true
-					break
-				}
-			}
-			if !found {
-				fmt.Printf("invalid filter key '%s' will be ignored\n", filter.Key)
+	expression, violations := parseFilters(in.Filters, in.Filter)
+	orders, orderViolations := toOrders(in.OrderBy, "orderBy")
+	violations = append(violations, orderViolations...)
+	columns, maskViolations := toColumns(in.ReadMask, "readMask")
+	violations = append(violations, maskViolations...)
+	options := db.Options{
+		Pageination: paginationSettings,
+		Expression:  expression,
+		OrderBy:     orders,
+		Columns:     columns,
+		Scope:       scopeOf(ctx),
+	}
+	var pageSize uint32
+	if usesPageTokens(in) {
+		var pageViolations []*errdetails.BadRequest_FieldViolation
+		pageSize, pageViolations = s.applyPageToken(in, &options)
+		violations = append(violations, pageViolations...)
+	}
+	if len(violations) > 0 {
+		endSpan(parseSpan, fmt.Errorf("invalid filter, order or page"))
+		return invalidArgument(violations...)
+	}
+
+	parseSpan.SetAttributes(attribute.Int("filters.valid", len(in.Filters)), attribute.Bool("filters.expression", expression != nil))
+	parseSpan.End()
+
+	dbAttributes := []attribute.KeyValue{attribute.Int("filters.count", len(in.Filters))}
+	if options.Pageination != nil {
+		dbAttributes = append(dbAttributes,
+			attribute.Int64("pagination.limit", int64(options.Pageination.Limit)),
+			attribute.Int64("pagination.offset", int64(options.Pageination.Offset)),
+			attribute.Bool("pagination.keyset", options.After != nil))
+	}
+	queryStart := time.Now()
+	dbCtx, dbSpan := startDBSpan(ctx, "Select", dbAttributes...)
+	data, err := s.db.Select(dbCtx, options)
+	dbSpan.SetAttributes(attribute.Int("db.rows_returned", len(data)))
+	endSpan(dbSpan, err)
+	if err != nil {
+		logger.Error("failed to select tanks", "error", err)
+		return errorStatus(ctx, err)
+	}
+	dbCtx, dbSpan = startDBSpan(ctx, "Count", attribute.Int("filters.count", len(in.Filters)))
+	total, err := s.db.Count(dbCtx, options)
+	endSpan(dbSpan, err)
+	if err != nil {
+		logger.Error("failed to count tanks", "error", err)
+		return errorStatus(ctx, err)
+	}
+	trailer := metadata.Pairs(QueryTimeTrailer, time.Since(queryStart).String())
+	if err := stream.SendHeader(metadata.Pairs(TotalCountHeader, strconv.FormatUint(total, 10))); err != nil {
+		logger.Error("failed to send header", "error", err)
+		return errorStatus(ctx, err)
+	}
+	switch {
+	case usesPageTokens(in):
+		if len(data) > int(pageSize) {
+			data = data[:pageSize]
+			token, err := s.nextPageToken(in, &options, data[len(data)-1])
+			if err != nil {
+				logger.Error("failed to create page token", "error", err)
+				return errorStatus(ctx, err)
 			}
+			trailer.Set(NextPageTokenTrailer, token)
+		}
+	case paginationSettings != nil && paginationSettings.Limit > 0:
+		if next := uint64(paginationSettings.Offset) + uint64(len(data)); next < total {
+			trailer.Set(NextOffsetTrailer, strconv.FormatUint(next, 10))
 		}
 	}
+	stream.SetTrailer(trailer)
 
-	data, _ := s.db.Select(db.Options{
-		Pageination: paginationSettings,
-		Filters:     filterSettings,
-	})
+	_, sendSpan := tracer.Start(ctx, "send tanks")
 	for _, tank := range data {
-		if err := stream.Send(mapToResponse(tank)); err != nil {
-			fmt.Printf("%v\n", err)
-			return status.Error(codes.Internal, "internal error")
+		if err := stream.Send(applyReadMask(mapToResponse(tank), in.ReadMask)); err != nil {
+			logger.Error("failed to send tank", "number", tank.Number, "error", err)
+			endSpan(sendSpan, err)
+			return errorStatus(ctx, err)
 		}
 	}
+	sendSpan.SetAttributes(attribute.Int("messages.sent", len(data)))
+	sendSpan.End()
+	logger.Info("streamed tanks", "count", len(data))
 	return nil
 }
 
-func (s *TankService) GetTank(_ context.Context, in *pb.GetTankRequest) (*pb.TankResponse, error) {
-	log.Printf("GET: received for %d\n", in.Number)
-	tank, err := s.db.SelectByNumber(in.Number)
+func (s *TankService) CountTanks(ctx context.Context, in *pb.CountTanksRequest) (*pb.CountTanksResponse, error) {
+	logger := logging.FromContext(ctx, s.logger)
+	logger.Info("count tanks", "filters", len(in.Filters))
+	expression, violations := parseFilters(in.Filters, in.Filter)
+	if len(violations) > 0 {
+		return nil, invalidArgument(violations...)
+	}
+	dbCtx, span := startDBSpan(ctx, "Count", attribute.Int("filters.count", len(in.Filters)))
+	count, err := s.db.Count(dbCtx, db.Options{
+		Expression: expression,
+		Scope:      scopeOf(ctx),
+	})
+	endSpan(span, err)
 	if err != nil {
-		log.Panic(err)
+		logger.Error("failed to count tanks", "error", err)
+		return nil, errorStatus(ctx, err)
 	}
-	if tank == nil {
-		return nil, status.Error(codes.NotFound, fmt.Sprintf("no tank with number %d found", in.Number))
+	return &pb.CountTanksResponse{Count: count}, nil
+}
+
+func (s *TankService) SearchTanks(ctx context.Context, in *pb.SearchTanksRequest) (*pb.SearchTanksResponse, error) {
+	logger := logging.FromContext(ctx, s.logger)
+	logger.Info("search tanks", "query", in.Query)
+	if strings.TrimSpace(in.Query) == "" {
+		return nil, invalidArgument(&errdetails.BadRequest_FieldViolation{Field: "query", Description: "query must not be empty"})
 	}
-	return mapToResponse(tank), nil
+	limit := in.Limit
+	switch {
+	case limit == 0:
+		limit = defaultSearchLimit
+	case limit > maxSearchLimit:
+		limit = maxSearchLimit
+	}
+	dbCtx, span := startDBSpan(ctx, "Search", attribute.Int64("search.limit", int64(limit)))
+	results, err := s.db.Search(dbCtx, in.Query, db.Options{
+		Pageination: &apiModel.Pageination{Limit: limit},
+		Scope:       scopeOf(ctx),
+	})
+	span.SetAttributes(attribute.Int("db.rows_returned", len(results)))
+	endSpan(span, err)
+	if err != nil {
+		logger.Error("failed to search tanks", "error", err)
+		return nil, errorStatus(ctx, err)
+	}
+	response := &pb.SearchTanksResponse{}
+	for _, result := range results {
+		response.Results = append(response.Results, &pb.SearchResult{
+			Tank:    mapToResponse(result.Tank),
+			Rank:    result.Rank,
+			Snippet: result.Snippet,
+		})
+	}
+	return response, nil
 }
 
-func (s *TankService) CreateTank(_ context.Context, in *pb.CreateTankRequest) (*pb.CreateTankResponse, error) {
-	log.Printf("CREATE: received for %v\n", in)
-	if in.Number == 0 {
-		return nil, status.Error(codes.InvalidArgument, "request needs to contain valid name")
+func (s *TankService) GetTank(ctx context.Context, in *pb.GetTankRequest) (*pb.TankResponse, error) {
+	logger := logging.FromContext(ctx, s.logger)
+	logger.Info("get tank")
+	columns, violations := toColumns(in.ReadMask, "readMask")
+	if len(violations) > 0 {
+		return nil, invalidArgument(violations...)
+	}
+	scope := scopeOf(ctx)
+	if len(columns) > 0 && !scope.Unrestricted() {
+		columns = append(columns, "system", "lab")
+	}
+	dbCtx, span := startDBSpan(ctx, "SelectByNumber", attribute.Int64("tank.number", int64(in.Number)))
+	tank, err := s.db.SelectByNumber(dbCtx, in.Number, columns...)
+	endSpan(span, err)
+	if errors.Is(err, db.ErrNotFound) {
+		return nil, tankNotFound(in.Number)
+	}
+	if err != nil {
+		logger.Error("failed to select tank", "error", err)
+		return nil, errorStatus(ctx, err)
 	}
+	if !scope.Allows(tank) {
+		return nil, tankNotFound(in.Number)
+	}
+	return applyReadMask(mapToResponse(tank), in.ReadMask), nil
+}
+
+func (s *TankService) CreateTank(ctx context.Context, in *pb.CreateTankRequest) (*pb.CreateTankResponse, error) {
+	logger := logging.FromContext(ctx, s.logger)
+	logger.Info("create tank", "system", in.System, "lab", in.Lab)
 	tank := &model.Tank{
-		Number:    in.Number,
-		System:    in.System,
-		Active:    in.Active,
-		Size:      in.Size,
-		FishCount: in.FishCount,
+		Number:      in.Number,
+		System:      in.System,
+		Active:      in.Active,
+		Size:        in.Size,
+		FishCount:   in.FishCount,
+		Lab:         in.Lab,
+		Line:        in.Line,
+		Notes:       in.Notes,
+		Responsible: in.Responsible,
+	}
+	if violations := validateTank(tank, ""); len(violations) > 0 {
+		return nil, invalidArgument(violations...)
 	}
-	err := s.db.Insert(tank)
+	if !scopeOf(ctx).Allows(tank) {
+		return nil, status.Error(codes.PermissionDenied, "tank is outside of your systems and labs")
+	}
+	dbCtx, span := startDBSpan(ctx, "Insert", attribute.Int64("tank.number", int64(in.Number)))
+	err := s.db.Insert(dbCtx, tank)
+	endSpan(span, err)
 	if err != nil {
-		switch err.Error() {
-		case string(db.TankAlreadyExists):
-			return nil, status.Error(codes.AlreadyExists, "tank already exists")
-		default:
-			return nil, status.Error(codes.Internal, "internal server error")
+		if !errors.Is(err, db.ErrConflict) {
+			logger.Error("failed to insert tank", "error", err)
 		}
+		return nil, errorStatus(ctx, err)
 	}
 	return &pb.CreateTankResponse{}, nil
 }
 
-func (s *TankService) UpdateTank(_ context.Context, in *pb.UpdateTankRequest) (*pb.UpdateTankResponse, error) {
-	log.Printf("UPDATE: received for %v\n", in)
-	entity, err := s.db.SelectByNumber(in.Number)
+func (s *TankService) UpdateTank(ctx context.Context, in *pb.UpdateTankRequest) (*pb.UpdateTankResponse, error) {
+	logger := logging.FromContext(ctx, s.logger)
+	logger.Info("update tank", "mask", in.GetMask().GetPaths())
+	if violations := validateUpdateMask(in.GetMask(), "mask"); len(violations) > 0 {
+		return nil, invalidArgument(violations...)
+	}
+	scope := scopeOf(ctx)
+	dbCtx, span := startDBSpan(ctx, "SelectByNumber", attribute.Int64("tank.number", int64(in.Number)))
+	entity, err := s.db.SelectByNumber(dbCtx, in.Number)
+	endSpan(span, err)
+	if errors.Is(err, db.ErrNotFound) {
+		return nil, tankNotFound(in.Number)
+	}
 	if err != nil {
-
+		logger.Error("failed to select tank", "error", err)
+		return nil, errorStatus(ctx, err)
+	}
+	if !scope.Allows(entity) {
+		return nil, tankNotFound(in.Number)
 	}
 	transformed := mapToProto(entity)
 	in.Mask.Normalize()
-	if !in.Mask.IsValid(transformed) {
-
+	applyMask(transformed, in.GetTank(), in.GetMask().GetPaths())
+	updated := mapToModel(transformed)
+	if violations := validateTank(updated, "tank.", in.GetMask().GetPaths()...); len(violations) > 0 {
+		return nil, invalidArgument(violations...)
 	}
-	fmutils.Filter(in.GetTank(), in.GetMask().GetPaths())
-	proto.Merge(transformed, in.GetTank())
-	err = s.db.Update(mapToModel(transformed))
+	if !scope.Allows(updated) {
+		return nil, status.Error(codes.PermissionDenied, "tank would be moved outside of your systems and labs")
+	}
+	dbCtx, span = startDBSpan(ctx, "Update", attribute.Int64("tank.number", int64(in.Number)))
+	err = s.db.Update(dbCtx, updated)
+	endSpan(span, err)
 	if err != nil {
-		return nil, err
+		logger.Error("failed to update tank", "error", err)
+		return nil, errorStatus(ctx, err)
 	}
 	return &pb.UpdateTankResponse{}, nil
 }
 
-func (s *TankService) DeleteTank(_ context.Context, in *pb.DeleteTankRequest) (*pb.DeleteTankResponse, error) {
-	log.Printf("DEL: received for %d\n", in.Number)
-	err := s.db.Delete(in.Number)
+func (s *TankService) DeleteTank(ctx context.Context, in *pb.DeleteTankRequest) (*pb.DeleteTankResponse, error) {
+	logger := logging.FromContext(ctx, s.logger)
+	logger.Info("delete tank")
+	if scope := scopeOf(ctx); !scope.Unrestricted() {
+		dbCtx, span := startDBSpan(ctx, "SelectByNumber", attribute.Int64("tank.number", int64(in.Number)))
+		tank, err := s.db.SelectByNumber(dbCtx, in.Number)
+		endSpan(span, err)
+		if errors.Is(err, db.ErrNotFound) {
+			return nil, tankNotFound(in.Number)
+		}
+		if err != nil {
+			logger.Error("failed to select tank", "error", err)
+			return nil, errorStatus(ctx, err)
+		}
+		if !scope.Allows(tank) {
+			return nil, tankNotFound(in.Number)
+		}
+	}
+	dbCtx, span := startDBSpan(ctx, "Delete", attribute.Int64("tank.number", int64(in.Number)))
+	err := s.db.Delete(dbCtx, in.Number)
+	endSpan(span, err)
 	if err != nil {
-
+		if !errors.Is(err, db.ErrNotFound) {
+			logger.Error("failed to delete tank", "error", err)
+		}
+		return nil, errorStatus(ctx, err)
 	}
 	return &pb.DeleteTankResponse{}, nil
 }
 
 func mapToResponse(tank *model.Tank) *pb.TankResponse {
 	return &pb.TankResponse{
-		Number:    tank.Number,
-		System:    tank.System,
-		Active:    tank.Active,
-		Size:      tank.Size,
-		FishCount: tank.FishCount,
+		Id:          tank.ID,
+		Number:      tank.Number,
+		System:      tank.System,
+		Active:      tank.Active,
+		Size:        tank.Size,
+		FishCount:   tank.FishCount,
+		Lab:         tank.Lab,
+		Line:        tank.Line,
+		Notes:       tank.Notes,
+		Responsible: tank.Responsible,
+		LastCleaned: tank.LastCleaned,
+		LastFed:     tank.LastFed,
 	}
 }
 func mapToProto(tank *model.Tank) *pb.Tank {
 	return &pb.Tank{
-		Number:    tank.Number,
-		System:    tank.System,
-		Active:    tank.Active,
-		Size:      tank.Size,
-		FishCount: tank.FishCount,
+		Number:      tank.Number,
+		System:      tank.System,
+		Active:      tank.Active,
+		Size:        tank.Size,
+		FishCount:   tank.FishCount,
+		Lab:         tank.Lab,
+		Line:        tank.Line,
+		Notes:       tank.Notes,
+		Responsible: tank.Responsible,
 	}
 }
 
 func mapToModel(tank *pb.Tank) *model.Tank {
 	return &model.Tank{
-		Number:    tank.Number,
-		System:    tank.System,
-		Active:    tank.Active,
-		Size:      tank.Size,
-		FishCount: tank.FishCount,
+		Number:      tank.Number,
+		System:      tank.System,
+		Active:      tank.Active,
+		Size:        tank.Size,
+		FishCount:   tank.FishCount,
+		Lab:         tank.Lab,
+		Line:        tank.Line,
+		Notes:       tank.Notes,
+		Responsible: tank.Responsible,
+	}
+}
+
+// applyMask copies the fields named in paths from src to dst. Unlike
+// proto.Merge, fields set to their zero value are copied as well.
+func applyMask(dst *pb.Tank, src *pb.Tank, paths []string) {
+	dstMessage, srcMessage := dst.ProtoReflect(), src.ProtoReflect()
+	fields := dstMessage.Descriptor().Fields()
+	for _, path := range paths {
+		if field := fields.ByName(protoreflect.Name(path)); field != nil {
+			dstMessage.Set(field, srcMessage.Get(field))
+		}
 	}
 }
 
-func New(db db.TankDB) *TankService {
+// scopeOf returns the tanks the caller of the RPC has access to.
+func scopeOf(ctx context.Context) *db.Scope {
+	principal, ok := auth.FromContext(ctx)
+	if !ok {
+		return nil
+	}
+	return &db.Scope{
+		All:     principal.AllTanks,
+		Systems: principal.Systems,
+		Labs:    principal.Labs,
+	}
+}
+
+func New(db db.TankDB, logger *slog.Logger) *TankService {
+	if logger == nil {
+		logger = slog.Default()
+	}
 	return &TankService{
-		db: db,
+		db:             db,
+		logger:         logger,
+		pageTokens:     pagetoken.NewRandom(),
+		labelTemplates: label.Templates,
 	}
 }
+
+// SetPageTokenKey signs page tokens with key, so they stay valid across
+// restarts and replicas sharing the key. By default a random key is used.
+func (s *TankService) SetPageTokenKey(key []byte) {
+	s.pageTokens = pagetoken.New(key)
+}
+
+// SetLabelTemplates replaces the built-in label templates.
+func (s *TankService) SetLabelTemplates(templates map[string]label.Template) {
+	s.labelTemplates = templates
+}
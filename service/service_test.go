@@ -2,25 +2,49 @@ package service_test
 
 import (
 	"context"
-	_ "google.golang.org/genproto/protobuf/field_mask"
+	"fmt"
+	"google.golang.org/genproto/googleapis/rpc/errdetails"
+	"google.golang.org/genproto/protobuf/field_mask"
 	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/metadata"
 	"google.golang.org/grpc/status"
+	"google.golang.org/protobuf/proto"
 	"math/rand"
+	"strings"
+	"sync"
 	"testing"
+	"time"
 
 	apiProto "github.com/anchamber/genetics-api/proto"
+	"github.com/anchamber/genetics-tank/auth"
 	"github.com/anchamber/genetics-tank/db"
 	sm "github.com/anchamber/genetics-tank/db/model"
+	"github.com/anchamber/genetics-tank/label"
 	tankProto "github.com/anchamber/genetics-tank/proto"
 	"github.com/anchamber/genetics-tank/service"
 	grpc "google.golang.org/grpc"
 )
 
 var testData = []*sm.Tank{
-	}
+	{System: "A", Number: 1, Active: true, Size: 10, FishCount: 5, Lab: "zebrafish"},
+	{System: "A", Number: 2, Active: false, Size: 20, FishCount: 0, Lab: "zebrafish"},
+	{System: "B", Number: 3, Active: true, Size: 10, FishCount: 12, Lab: "medaka"},
+	{System: "B2", Number: 4, Active: true, Size: 5, FishCount: 3, Lab: "medaka"},
+}
 
 var testTanksToCreate = []*sm.Tank{
+	{System: "C", Number: 10, Active: true, Size: 10, FishCount: 0, Lab: "zebrafish"},
+	{System: "C", Number: 11, Active: false, Size: 5, FishCount: 0, Lab: "medaka"},
+}
+
+func newMockDB(t testing.TB, tanks []*sm.Tank) db.TankDBMock {
+	t.Helper()
+	tankDB, err := db.NewMockDB(tanks, nil)
+	if err != nil {
+		t.Fatalf("failed to create database: %v", err)
 	}
+	return tankDB
+}
 
 func TestStreamTanks(t *testing.T) {
 	testCases := []struct {
@@ -28,11 +52,12 @@ func TestStreamTanks(t *testing.T) {
 		request       *tankProto.StreamTanksRequest
 		responses     []*sm.Tank
 		expectedError bool
+		errorCode     codes.Code
 	}{
 		{
 			name:          "request all entries",
 			request:       &tankProto.StreamTanksRequest{},
-			responses:     db.MockDataTanks,
+			responses:     testData,
 			expectedError: false,
 		},
 		{
@@ -42,7 +67,7 @@ func TestStreamTanks(t *testing.T) {
 					Limit: 2,
 				},
 			},
-			responses:     db.MockDataTanks[0:2],
+			responses:     testData[0:2],
 			expectedError: false,
 		},
 		{
@@ -52,7 +77,7 @@ func TestStreamTanks(t *testing.T) {
 					Offset: 2,
 				},
 			},
-			responses:     db.MockDataTanks[2:],
+			responses:     testData[2:],
 			expectedError: false,
 		},
 		{
@@ -63,36 +88,39 @@ func TestStreamTanks(t *testing.T) {
 					Limit:  1,
 				},
 			},
-			responses:     db.MockDataTanks[2:3],
+			responses:     testData[2:3],
 			expectedError: false,
 		},
 		{
-			name: "request with name filter EQ",
+			name: "request with system filter EQ",
 			request: &tankProto.StreamTanksRequest{
 				Filters: []*apiProto.Filter{
 					{
-						Key:      "name",
+						Key:      "system",
 						Operator: apiProto.Operator_EQ,
+						Value:    "B",
 					},
 				},
 			},
 			responses: []*sm.Tank{
-				db.MockDataTanks[1],
+				testData[2],
 			},
 			expectedError: false,
 		},
 		{
-			name: "request with name filter CONTAINS",
+			name: "request with system filter CONTAINS",
 			request: &tankProto.StreamTanksRequest{
 				Filters: []*apiProto.Filter{
 					{
-						Key:      "name",
+						Key:      "system",
 						Operator: apiProto.Operator_CONTAINS,
+						Value:    "B",
 					},
 				},
 			},
 			responses: []*sm.Tank{
-				db.MockDataTanks[2],
+				testData[2],
+				testData[3],
 			},
 			expectedError: false,
 		},
@@ -106,12 +134,99 @@ func TestStreamTanks(t *testing.T) {
 					},
 				},
 			},
-			responses:     db.MockDataTanks,
+			expectedError: true,
+			errorCode:     codes.InvalidArgument,
+		},
+		{
+			name: "request with boolean filter value",
+			request: &tankProto.StreamTanksRequest{
+				Filters: []*apiProto.Filter{{Key: "active", Operator: apiProto.Operator_EQ, Value: "true"}},
+			},
+			responses:     []*sm.Tank{testData[0], testData[2], testData[3]},
+			expectedError: false,
+		},
+		{
+			name: "request with numeric filter value",
+			request: &tankProto.StreamTanksRequest{
+				Filters: []*apiProto.Filter{{Key: "size", Operator: apiProto.Operator_GREATER_EQ, Value: "10"}},
+			},
+			responses:     testData[0:3],
+			expectedError: false,
+		},
+		{
+			name: "request with invalid numeric filter value",
+			request: &tankProto.StreamTanksRequest{
+				Filters: []*apiProto.Filter{{Key: "size", Operator: apiProto.Operator_EQ, Value: "ten"}},
+			},
+			expectedError: true,
+			errorCode:     codes.InvalidArgument,
+		},
+		{
+			name: "request with unsupported filter operator",
+			request: &tankProto.StreamTanksRequest{
+				Filters: []*apiProto.Filter{{Key: "active", Operator: apiProto.Operator_CONTAINS, Value: "true"}},
+			},
+			expectedError: true,
+			errorCode:     codes.InvalidArgument,
+		},
+		{
+			name: "request with BETWEEN on boolean key",
+			request: &tankProto.StreamTanksRequest{
+				Filter: &tankProto.FilterExpression{Expression: &tankProto.FilterExpression_Between{Between: &tankProto.BetweenFilter{Key: "active", Lower: "false", Upper: "true"}}},
+			},
+			expectedError: true,
+			errorCode:     codes.InvalidArgument,
+		},
+		{
+			name: "request with OR and NOT expression",
+			request: &tankProto.StreamTanksRequest{
+				Filter: and(
+					or(condition("system", apiProto.Operator_EQ, "A"), condition("system", apiProto.Operator_EQ, "B")),
+					&tankProto.FilterExpression{Expression: &tankProto.FilterExpression_Not{Not: condition("active", apiProto.Operator_EQ, "0")}},
+				),
+			},
+			responses:     []*sm.Tank{testData[0], testData[2]},
 			expectedError: false,
 		},
+		{
+			name: "request with IN and BETWEEN expression combined with filters",
+			request: &tankProto.StreamTanksRequest{
+				Filters: []*apiProto.Filter{{Key: "lab", Operator: apiProto.Operator_EQ, Value: "zebrafish"}},
+				Filter: and(
+					&tankProto.FilterExpression{Expression: &tankProto.FilterExpression_In{In: &tankProto.InFilter{Key: "system", Values: []string{"A", "B2"}}}},
+					&tankProto.FilterExpression{Expression: &tankProto.FilterExpression_Between{Between: &tankProto.BetweenFilter{Key: "number", Lower: "2", Upper: "4"}}},
+				),
+			},
+			responses:     []*sm.Tank{testData[1]},
+			expectedError: false,
+		},
+		{
+			name: "request ordered by size descending",
+			request: &tankProto.StreamTanksRequest{
+				OrderBy: []*tankProto.OrderBy{{Key: "size", Descending: true}},
+			},
+			responses:     []*sm.Tank{testData[1], testData[0], testData[2], testData[3]},
+			expectedError: false,
+		},
+		{
+			name: "request ordered by invalid key",
+			request: &tankProto.StreamTanksRequest{
+				OrderBy: []*tankProto.OrderBy{{Key: "number; DROP TABLE tanks"}},
+			},
+			expectedError: true,
+			errorCode:     codes.InvalidArgument,
+		},
+		{
+			name: "request with invalid expression key",
+			request: &tankProto.StreamTanksRequest{
+				Filter: or(condition("system", apiProto.Operator_EQ, "A"), condition("system; --", apiProto.Operator_EQ, "B")),
+			},
+			expectedError: true,
+			errorCode:     codes.InvalidArgument,
+		},
 	}
 
-	tankServer := service.New(db.NewMockDB(testData))
+	tankServer := service.New(newMockDB(t, testData), nil)
 	for _, tc := range testCases {
 		tc := tc
 		t.Run(tc.name, func(t *testing.T) {
@@ -122,7 +237,7 @@ func TestStreamTanks(t *testing.T) {
 				responses: tc.responses,
 			}
 			err := tankServer.StreamTanks(tc.request, &serviceMock)
-			if validateError(t, err, codes.Unknown, tc.expectedError) {
+			if validateError(t, err, tc.errorCode, tc.expectedError) {
 				return
 			}
 			if serviceMock.CallCount != len(tc.responses) {
@@ -132,6 +247,122 @@ func TestStreamTanks(t *testing.T) {
 	}
 }
 
+func condition(key string, operator apiProto.Operator, value string) *tankProto.FilterExpression {
+	return &tankProto.FilterExpression{Expression: &tankProto.FilterExpression_Condition{
+		Condition: &apiProto.Filter{Key: key, Operator: operator, Value: value},
+	}}
+}
+
+func and(expressions ...*tankProto.FilterExpression) *tankProto.FilterExpression {
+	return &tankProto.FilterExpression{Expression: &tankProto.FilterExpression_And{
+		And: &tankProto.FilterExpressionList{Expressions: expressions},
+	}}
+}
+
+func or(expressions ...*tankProto.FilterExpression) *tankProto.FilterExpression {
+	return &tankProto.FilterExpression{Expression: &tankProto.FilterExpression_Or{
+		Or: &tankProto.FilterExpressionList{Expressions: expressions},
+	}}
+}
+
+func TestStreamTanksWithPageTokens(t *testing.T) {
+	tankDB := newMockDB(t, testData)
+	tankServer := service.New(tankDB, nil)
+	request := &tankProto.StreamTanksRequest{
+		OrderBy:  []*tankProto.OrderBy{{Key: "size", Descending: true}},
+		PageSize: 2,
+	}
+
+	first := MockTankService{t: t, responses: []*sm.Tank{testData[1], testData[0]}}
+	if err := tankServer.StreamTanks(request, &first); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	tokens := first.trailer.Get(service.NextPageTokenTrailer)
+	if len(tokens) != 1 {
+		t.Fatalf("expected a next page token, got: %v", tokens)
+	}
+
+	// tanks inserted before the current position don't shift the next page
+	if err := tankDB.Insert(context.Background(), &sm.Tank{System: "C", Number: 20, Size: 30}); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	request.PageToken = tokens[0]
+	second := MockTankService{t: t, responses: []*sm.Tank{testData[2], testData[3]}}
+	if err := tankServer.StreamTanks(request, &second); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if second.CallCount != 2 || len(second.trailer.Get(service.NextPageTokenTrailer)) != 0 {
+		t.Errorf("expected last page with 2 tanks, got %d tanks and trailer %v", second.CallCount, second.trailer)
+	}
+
+	request.OrderBy = nil
+	err := tankServer.StreamTanks(request, &MockTankService{t: t})
+	validateError(t, err, codes.InvalidArgument, true)
+}
+
+func TestStreamTanksMetadata(t *testing.T) {
+	tankServer := service.New(newMockDB(t, testData), nil)
+	serviceMock := MockTankService{t: t, responses: testData[1:3]}
+	err := tankServer.StreamTanks(&tankProto.StreamTanksRequest{
+		Pageination: &apiProto.Pagination{Offset: 1, Limit: 2},
+	}, &serviceMock)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if total := serviceMock.header.Get(service.TotalCountHeader); len(total) != 1 || total[0] != "4" {
+		t.Errorf("wrong total count: %v", total)
+	}
+	if next := serviceMock.trailer.Get(service.NextOffsetTrailer); len(next) != 1 || next[0] != "3" {
+		t.Errorf("wrong next offset: %v", next)
+	}
+	if len(serviceMock.trailer.Get(service.QueryTimeTrailer)) != 1 {
+		t.Error("query time missing")
+	}
+}
+
+func TestReadMask(t *testing.T) {
+	tankServer := service.New(newMockDB(t, testData), nil)
+	mask := &field_mask.FieldMask{Paths: []string{"number", "fishCount"}}
+	expected := &tankProto.TankResponse{Number: testData[2].Number, FishCount: testData[2].FishCount}
+
+	resp, err := tankServer.GetTank(context.Background(), &tankProto.GetTankRequest{Number: testData[2].Number, ReadMask: mask})
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if !proto.Equal(resp, expected) {
+		t.Errorf("wrong response, expected: %v | actual: %v", expected, resp)
+	}
+
+	scoped := auth.NewContext(context.Background(), &auth.Principal{Subject: "medaka-tech", Labs: []string{"medaka"}})
+	resp, err = tankServer.GetTank(scoped, &tankProto.GetTankRequest{Number: testData[2].Number, ReadMask: mask})
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if !proto.Equal(resp, expected) {
+		t.Errorf("wrong scoped response, expected: %v | actual: %v", expected, resp)
+	}
+
+	_, err = tankServer.GetTank(context.Background(), &tankProto.GetTankRequest{
+		Number:   testData[2].Number,
+		ReadMask: &field_mask.FieldMask{Paths: []string{"fish_count"}},
+	})
+	validateError(t, err, codes.InvalidArgument, true)
+}
+
+func TestCountTanks(t *testing.T) {
+	tankServer := service.New(newMockDB(t, testData), nil)
+	resp, err := tankServer.CountTanks(context.Background(), &tankProto.CountTanksRequest{
+		Filters: []*apiProto.Filter{{Key: "lab", Operator: apiProto.Operator_EQ, Value: "medaka"}},
+		Filter:  condition("system", apiProto.Operator_CONTAINS, "2"),
+	})
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if resp.Count != 1 {
+		t.Errorf("wrong count, expected: 1 | actual: %d", resp.Count)
+	}
+}
+
 func TestGetTank(t *testing.T) {
 	index := rand.Intn(len(testData))
 	testCases := []struct {
@@ -146,6 +377,7 @@ func TestGetTank(t *testing.T) {
 			response:      testData[index],
 			expectedError: false,
 			request: &tankProto.GetTankRequest{
+				Number: testData[index].Number,
 			},
 		},
 		{
@@ -159,7 +391,7 @@ func TestGetTank(t *testing.T) {
 		},
 	}
 
-	tankServer := service.New(db.NewMockDB(testData))
+	tankServer := service.New(newMockDB(t, testData), nil)
 	for _, tc := range testCases {
 		tc := tc
 		t.Run(tc.name, func(t *testing.T) {
@@ -187,28 +419,32 @@ func TestCreateTank(t *testing.T) {
 			response:      tank,
 			expectedError: false,
 			request: &tankProto.CreateTankRequest{
+				System:    tank.System,
+				Number:    tank.Number,
+				Active:    tank.Active,
+				Size:      tank.Size,
+				FishCount: tank.FishCount,
+				Lab:       tank.Lab,
 			},
 		},
 		{
 			name:          "create tank with invalid name",
 			response:      nil,
 			expectedError: true,
-			request: &tankProto.CreateTankRequest{
-			},
-			errorCode: codes.InvalidArgument,
+			request:       &tankProto.CreateTankRequest{},
+			errorCode:     codes.InvalidArgument,
 		},
 		{
 			name:          "create tank with invalid cleaning interval",
 			response:      nil,
 			expectedError: true,
-			request: &tankProto.CreateTankRequest{
-			},
-			errorCode: codes.InvalidArgument,
+			request:       &tankProto.CreateTankRequest{},
+			errorCode:     codes.InvalidArgument,
 		},
 	}
 
 	for _, tc := range testCases {
-		tankServer := service.New(db.NewMockDB(testData))
+		tankServer := service.New(newMockDB(t, testData), nil)
 		tc := tc
 		t.Run(tc.name, func(t *testing.T) {
 			t.Parallel()
@@ -232,16 +468,36 @@ func TestUpdateTank(t *testing.T) {
 		expectedError bool
 		errorCode     codes.Code
 	}{
+		{
+			name: "update system of tank",
+			request: &tankProto.UpdateTankRequest{
+				Number: tank.Number,
+				Tank: &tankProto.Tank{
+					System: "Z",
+					Size:   99,
+				},
+				Mask: &field_mask.FieldMask{Paths: []string{"system"}},
+			},
+			expected: sm.Tank{
+				System:    "Z",
+				Number:    tank.Number,
+				Active:    tank.Active,
+				Size:      tank.Size,
+				FishCount: tank.FishCount,
+				Lab:       tank.Lab,
+			},
+			expectedError: false,
+		},
 	}
 
 	for _, tc := range testCases {
-		tankServer := service.New(db.NewMockDB(testData))
+		tankServer := service.New(newMockDB(t, testData), nil)
 		tc := tc
 		t.Run(tc.name, func(t *testing.T) {
 			t.Parallel()
 			_, err := tankServer.UpdateTank(context.Background(), tc.request)
 			validateError(t, err, tc.errorCode, tc.expectedError)
-			resp, err := tankServer.GetTank(context.Background(), &tankProto.GetTankRequest{Name: tc.expected.Name})
+			resp, err := tankServer.GetTank(context.Background(), &tankProto.GetTankRequest{Number: tc.expected.Number})
 			if validateError(t, err, tc.errorCode, tc.expectedError) {
 				return
 			}
@@ -262,6 +518,7 @@ func TestDeleteTank(t *testing.T) {
 		{
 			name: "delete existing tank",
 			request: &tankProto.DeleteTankRequest{
+				Number: tank.Number,
 			},
 			expectedErrorDel: false,
 			expectedErrorGet: true,
@@ -273,12 +530,12 @@ func TestDeleteTank(t *testing.T) {
 		tc := tc
 		t.Run(tc.name, func(t *testing.T) {
 			t.Parallel()
-			tankServer := service.New(db.NewMockDB(testData))
+			tankServer := service.New(newMockDB(t, testData), nil)
 			_, err := tankServer.DeleteTank(context.Background(), tc.request)
 			if validateError(t, err, tc.errorCode, tc.expectedErrorDel) {
 				return
 			}
-			resp, err := tankServer.GetTank(context.Background(), &tankProto.GetTankRequest{Name: tank.Number})
+			resp, err := tankServer.GetTank(context.Background(), &tankProto.GetTankRequest{Number: tank.Number})
 			if validateError(t, err, tc.errorCode, tc.expectedErrorGet) {
 				return
 			}
@@ -287,13 +544,468 @@ func TestDeleteTank(t *testing.T) {
 	}
 }
 
+func TestScopedAccess(t *testing.T) {
+	ctx := auth.NewContext(context.Background(), &auth.Principal{
+		Subject: "medaka-tech",
+		Roles:   []auth.Role{auth.Admin},
+		Labs:    []string{"medaka"},
+	})
+	outOfScope := testData[0]
+	inScope := testData[2]
+	tankServer := service.New(newMockDB(t, testData), nil)
+
+	t.Run("stream only returns tanks in scope", func(t *testing.T) {
+		serviceMock := MockTankService{
+			t:         t,
+			ctx:       ctx,
+			responses: testData[2:],
+		}
+		err := tankServer.StreamTanks(&tankProto.StreamTanksRequest{}, &serviceMock)
+		if validateError(t, err, codes.Unknown, false) {
+			return
+		}
+		if serviceMock.CallCount != len(serviceMock.responses) {
+			t.Errorf("Call count of mock does not match, expected: %d | actual: %d", len(serviceMock.responses), serviceMock.CallCount)
+		}
+	})
+	t.Run("get tank in scope", func(t *testing.T) {
+		resp, err := tankServer.GetTank(ctx, &tankProto.GetTankRequest{Number: inScope.Number})
+		if validateError(t, err, codes.OK, false) {
+			return
+		}
+		compareResponseToTank(t, resp, inScope)
+	})
+	t.Run("get tank out of scope", func(t *testing.T) {
+		_, err := tankServer.GetTank(ctx, &tankProto.GetTankRequest{Number: outOfScope.Number})
+		validateError(t, err, codes.NotFound, true)
+	})
+	t.Run("update tank out of scope", func(t *testing.T) {
+		_, err := tankServer.UpdateTank(ctx, &tankProto.UpdateTankRequest{
+			Number: outOfScope.Number,
+			Tank:   &tankProto.Tank{Size: 1},
+			Mask:   &field_mask.FieldMask{Paths: []string{"size"}},
+		})
+		validateError(t, err, codes.NotFound, true)
+	})
+	t.Run("move tank out of scope", func(t *testing.T) {
+		_, err := tankServer.UpdateTank(ctx, &tankProto.UpdateTankRequest{
+			Number: inScope.Number,
+			Tank:   &tankProto.Tank{Lab: "zebrafish"},
+			Mask:   &field_mask.FieldMask{Paths: []string{"lab"}},
+		})
+		validateError(t, err, codes.PermissionDenied, true)
+	})
+	t.Run("delete tank out of scope", func(t *testing.T) {
+		_, err := tankServer.DeleteTank(ctx, &tankProto.DeleteTankRequest{Number: outOfScope.Number})
+		validateError(t, err, codes.NotFound, true)
+	})
+}
+
+func TestEmptyScope(t *testing.T) {
+	tankServer := service.New(newMockDB(t, testData), nil)
+	empty := auth.NewContext(context.Background(), &auth.Principal{Subject: "admin", Roles: []auth.Role{auth.Admin}})
+	all := auth.NewContext(context.Background(), &auth.Principal{Subject: "admin", Roles: []auth.Role{auth.Admin}, AllTanks: true})
+
+	t.Run("stream returns no tanks", func(t *testing.T) {
+		serviceMock := MockTankService{t: t, ctx: empty}
+		err := tankServer.StreamTanks(&tankProto.StreamTanksRequest{}, &serviceMock)
+		if validateError(t, err, codes.Unknown, false) {
+			return
+		}
+		if serviceMock.CallCount != 0 {
+			t.Errorf("Call count of mock does not match, expected: %d | actual: %d", 0, serviceMock.CallCount)
+		}
+	})
+	t.Run("get tank", func(t *testing.T) {
+		_, err := tankServer.GetTank(empty, &tankProto.GetTankRequest{Number: testData[0].Number})
+		validateError(t, err, codes.NotFound, true)
+	})
+	t.Run("delete tank", func(t *testing.T) {
+		_, err := tankServer.DeleteTank(empty, &tankProto.DeleteTankRequest{Number: testData[0].Number})
+		validateError(t, err, codes.NotFound, true)
+	})
+	t.Run("get tank with access to all tanks", func(t *testing.T) {
+		resp, err := tankServer.GetTank(all, &tankProto.GetTankRequest{Number: testData[0].Number})
+		if validateError(t, err, codes.OK, false) {
+			return
+		}
+		compareResponseToTank(t, resp, testData[0])
+	})
+}
+
+func TestValidation(t *testing.T) {
+	tankServer := service.New(newMockDB(t, testData), nil)
+	testCases := []struct {
+		name       string
+		call       func() error
+		violations []string
+	}{
+		{
+			name: "create reports all violations",
+			call: func() error {
+				_, err := tankServer.CreateTank(context.Background(), &tankProto.CreateTankRequest{FishCount: service.MaxFishCount + 1})
+				return err
+			},
+			violations: []string{"number", "system", "size", "fishCount"},
+		},
+		{
+			name: "update without mask",
+			call: func() error {
+				_, err := tankServer.UpdateTank(context.Background(), &tankProto.UpdateTankRequest{Number: 1, Tank: &tankProto.Tank{}})
+				return err
+			},
+			violations: []string{"mask"},
+		},
+		{
+			name: "update with unknown and immutable fields",
+			call: func() error {
+				_, err := tankServer.UpdateTank(context.Background(), &tankProto.UpdateTankRequest{
+					Number: 1,
+					Tank:   &tankProto.Tank{},
+					Mask:   &field_mask.FieldMask{Paths: []string{"size", "name", "number"}},
+				})
+				return err
+			},
+			violations: []string{"mask.paths[1]", "mask.paths[2]"},
+		},
+		{
+			name: "update only validates masked fields",
+			call: func() error {
+				_, err := tankServer.UpdateTank(context.Background(), &tankProto.UpdateTankRequest{
+					Number: 2,
+					Tank:   &tankProto.Tank{Size: 0, System: ""},
+					Mask:   &field_mask.FieldMask{Paths: []string{"size"}},
+				})
+				return err
+			},
+			violations: []string{"tank.size"},
+		},
+	}
+
+	for _, tc := range testCases {
+		tc := tc
+		t.Run(tc.name, func(t *testing.T) {
+			err := tc.call()
+			validateError(t, err, codes.InvalidArgument, true)
+			var fields []string
+			for _, violation := range findDetail[*errdetails.BadRequest](t, err).FieldViolations {
+				fields = append(fields, violation.Field)
+			}
+			if fmt.Sprint(fields) != fmt.Sprint(tc.violations) {
+				t.Errorf("wrong violations, expected: %v | actual: %v", tc.violations, fields)
+			}
+		})
+	}
+}
+
+func TestGenerateTankLabels(t *testing.T) {
+	testCases := []struct {
+		name          string
+		request       *tankProto.GenerateTankLabelsRequest
+		contentType   string
+		contains      string
+		count         int
+		expectedError bool
+		errorCode     codes.Code
+	}{
+		{
+			name:        "svg by number",
+			request:     &tankProto.GenerateTankLabelsRequest{Numbers: []uint32{1, 3}},
+			contentType: "image/svg+xml",
+			contains:    "#3",
+			count:       1,
+		},
+		{
+			name: "zpl by filter",
+			request: &tankProto.GenerateTankLabelsRequest{
+				Filters: []*apiProto.Filter{{Key: "system", Operator: apiProto.Operator_EQ, Value: "A"}},
+				Format:  tankProto.LabelFormat_ZPL,
+			},
+			contentType: "application/zpl",
+			contains:    "^XA",
+			count:       2,
+		},
+		{
+			name:        "png with qr code",
+			request:     &tankProto.GenerateTankLabelsRequest{Numbers: []uint32{4}, Format: tankProto.LabelFormat_PNG, Symbology: tankProto.Symbology_QR},
+			contentType: "image/png",
+			contains:    "\x89PNG",
+			count:       1,
+		},
+		{
+			name:          "none existing tank",
+			request:       &tankProto.GenerateTankLabelsRequest{Numbers: []uint32{1, 999}},
+			expectedError: true,
+			errorCode:     codes.NotFound,
+		},
+		{
+			name:          "unknown template",
+			request:       &tankProto.GenerateTankLabelsRequest{Numbers: []uint32{1}, Template: "unknown"},
+			expectedError: true,
+			errorCode:     codes.InvalidArgument,
+		},
+		{
+			name: "invalid filter key",
+			request: &tankProto.GenerateTankLabelsRequest{
+				Filters: []*apiProto.Filter{{Key: "unknown", Operator: apiProto.Operator_EQ, Value: "A"}},
+			},
+			expectedError: true,
+			errorCode:     codes.InvalidArgument,
+		},
+	}
+
+	tankServer := service.New(newMockDB(t, testData), nil)
+	for _, tc := range testCases {
+		tc := tc
+		t.Run(tc.name, func(t *testing.T) {
+			t.Parallel()
+			resp, err := tankServer.GenerateTankLabels(context.Background(), tc.request)
+			if validateError(t, err, tc.errorCode, tc.expectedError) {
+				return
+			}
+			if resp.ContentType != tc.contentType {
+				t.Errorf("wrong content type, expected: %s | actual: %s", tc.contentType, resp.ContentType)
+			}
+			if len(resp.Pages) != 1 {
+				t.Fatalf("wrong number of pages, expected: 1 | actual: %d", len(resp.Pages))
+			}
+			if count := strings.Count(string(resp.Pages[0]), tc.contains); count != tc.count {
+				t.Errorf("wrong count of %q, expected: %d | actual: %d", tc.contains, tc.count, count)
+			}
+		})
+	}
+}
+
+func TestScanAction(t *testing.T) {
+	start := time.Now().Unix()
+	zebrafish := auth.NewContext(context.Background(), &auth.Principal{Subject: "zebrafish-tech", Labs: []string{"zebrafish"}})
+	testCases := []struct {
+		name          string
+		ctx           context.Context
+		request       *tankProto.ScanActionRequest
+		check         func(t *testing.T, resp *tankProto.TankResponse)
+		expectedError bool
+		errorCode     codes.Code
+	}{
+		{
+			name:    "mark cleaned",
+			request: &tankProto.ScanActionRequest{Payload: label.Payload(1), Action: tankProto.TankAction_CLEANED},
+			check: func(t *testing.T, resp *tankProto.TankResponse) {
+				if resp.LastCleaned < start {
+					t.Errorf("last cleaned not set: %d", resp.LastCleaned)
+				}
+			},
+		},
+		{
+			name:    "mark fed with version 0 label",
+			request: &tankProto.ScanActionRequest{Payload: "3\r\n", Action: tankProto.TankAction_FED},
+			check: func(t *testing.T, resp *tankProto.TankResponse) {
+				if resp.Number != 3 || resp.LastFed < start {
+					t.Errorf("last fed not set: %v", resp)
+				}
+			},
+		},
+		{
+			name:    "record mortality",
+			request: &tankProto.ScanActionRequest{Payload: label.Payload(3), Action: tankProto.TankAction_MORTALITY, Count: 2},
+			check: func(t *testing.T, resp *tankProto.TankResponse) {
+				if resp.FishCount != 10 {
+					t.Errorf("wrong fish count, expected: 10 | actual: %d", resp.FishCount)
+				}
+			},
+		},
+		{
+			name:          "mortality above fish count",
+			request:       &tankProto.ScanActionRequest{Payload: label.Payload(4), Action: tankProto.TankAction_MORTALITY, Count: 4},
+			expectedError: true,
+			errorCode:     codes.FailedPrecondition,
+		},
+		{
+			name:          "mortality without count",
+			request:       &tankProto.ScanActionRequest{Payload: label.Payload(4), Action: tankProto.TankAction_MORTALITY},
+			expectedError: true,
+			errorCode:     codes.InvalidArgument,
+		},
+		{
+			name:          "unspecified action",
+			request:       &tankProto.ScanActionRequest{Payload: label.Payload(4)},
+			expectedError: true,
+			errorCode:     codes.InvalidArgument,
+		},
+		{
+			name:          "invalid payload",
+			request:       &tankProto.ScanActionRequest{Payload: "https://example.com", Action: tankProto.TankAction_FED},
+			expectedError: true,
+			errorCode:     codes.InvalidArgument,
+		},
+		{
+			name:          "none existing tank",
+			request:       &tankProto.ScanActionRequest{Payload: label.Payload(999), Action: tankProto.TankAction_FED},
+			expectedError: true,
+			errorCode:     codes.NotFound,
+		},
+		{
+			name:          "tank outside of scope",
+			ctx:           zebrafish,
+			request:       &tankProto.ScanActionRequest{Payload: label.Payload(3), Action: tankProto.TankAction_FED},
+			expectedError: true,
+			errorCode:     codes.NotFound,
+		},
+	}
+
+	tankServer := service.New(newMockDB(t, testData), nil)
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			ctx := tc.ctx
+			if ctx == nil {
+				ctx = context.Background()
+			}
+			resp, err := tankServer.ScanAction(ctx, tc.request)
+			if validateError(t, err, tc.errorCode, tc.expectedError) {
+				return
+			}
+			tc.check(t, resp)
+		})
+	}
+
+	// The actions are stored and kept by updates of other fields.
+	_, err := tankServer.UpdateTank(context.Background(), &tankProto.UpdateTankRequest{
+		Number: 1,
+		Tank:   &tankProto.Tank{Notes: "checked"},
+		Mask:   &field_mask.FieldMask{Paths: []string{"notes"}},
+	})
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	resp, err := tankServer.ResolveScan(context.Background(), &tankProto.ResolveScanRequest{Payload: "]C0" + label.Payload(1)})
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if resp.LastCleaned < start || resp.Notes != "checked" {
+		t.Errorf("scan action not stored: %v", resp)
+	}
+	_, err = tankServer.ResolveScan(context.Background(), &tankProto.ResolveScanRequest{Payload: "T2:1"})
+	validateError(t, err, codes.InvalidArgument, true)
+}
+
+func TestConcurrentScanActions(t *testing.T) {
+	tank := testData[2]
+	tankServer := service.New(newMockDB(t, testData), nil)
+	scans := int(tank.FishCount) + 3
+	codesSeen := make(chan codes.Code, scans)
+	var wg sync.WaitGroup
+	for i := 0; i < scans; i++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			_, err := tankServer.ScanAction(context.Background(), &tankProto.ScanActionRequest{
+				Payload: label.Payload(tank.Number),
+				Action:  tankProto.TankAction_MORTALITY,
+				Count:   1,
+			})
+			codesSeen <- status.Code(err)
+		}()
+	}
+	wg.Add(1)
+	go func() {
+		defer wg.Done()
+		_, err := tankServer.ScanAction(context.Background(), &tankProto.ScanActionRequest{Payload: label.Payload(tank.Number), Action: tankProto.TankAction_CLEANED})
+		if err != nil {
+			t.Errorf("unexpected error: %v", err)
+		}
+	}()
+	wg.Wait()
+	close(codesSeen)
+
+	count := make(map[codes.Code]int)
+	for code := range codesSeen {
+		count[code]++
+	}
+	if count[codes.OK] != int(tank.FishCount) || count[codes.FailedPrecondition] != 3 {
+		t.Errorf("wrong results, expected %d OK and 3 FailedPrecondition | actual: %v", tank.FishCount, count)
+	}
+	resp, err := tankServer.GetTank(context.Background(), &tankProto.GetTankRequest{Number: tank.Number})
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if resp.FishCount != 0 || resp.LastCleaned == 0 {
+		t.Errorf("concurrent scans were lost: %v", resp)
+	}
+}
+
+func TestErrorDetails(t *testing.T) {
+	tankServer := service.New(newMockDB(t, testData), nil)
+	existing := testData[0]
+
+	_, err := tankServer.CreateTank(context.Background(), &tankProto.CreateTankRequest{System: existing.System, Number: existing.Number, Size: existing.Size})
+	validateError(t, err, codes.AlreadyExists, true)
+	if info := findDetail[*errdetails.ResourceInfo](t, err); info.ResourceName != fmt.Sprintf("tanks/%d", existing.Number) {
+		t.Errorf("wrong resource name: %s", info.ResourceName)
+	}
+
+	_, err = tankServer.DeleteTank(context.Background(), &tankProto.DeleteTankRequest{Number: 999})
+	validateError(t, err, codes.NotFound, true)
+	findDetail[*errdetails.ResourceInfo](t, err)
+
+	_, err = tankServer.CreateTank(context.Background(), &tankProto.CreateTankRequest{})
+	validateError(t, err, codes.InvalidArgument, true)
+	if badRequest := findDetail[*errdetails.BadRequest](t, err); len(badRequest.FieldViolations) == 0 {
+		t.Error("expected field violations")
+	}
+}
+
+func findDetail[T any](t *testing.T, err error) T {
+	t.Helper()
+	for _, detail := range status.Convert(err).Details() {
+		if d, ok := detail.(T); ok {
+			return d
+		}
+	}
+	var zero T
+	t.Fatalf("status has no detail of type %T: %v", zero, err)
+	return zero
+}
+
+func TestCancelledRequest(t *testing.T) {
+	tankServer := service.New(newMockDB(t, testData), nil)
+
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+	_, err := tankServer.GetTank(ctx, &tankProto.GetTankRequest{Number: testData[0].Number})
+	validateError(t, err, codes.Canceled, true)
+
+	ctx, cancel = context.WithTimeout(context.Background(), -time.Second)
+	defer cancel()
+	err = tankServer.StreamTanks(&tankProto.StreamTanksRequest{}, &MockTankService{t: t, ctx: ctx})
+	validateError(t, err, codes.DeadlineExceeded, true)
+}
+
 type MockTankService struct {
 	CallCount int
 	t         *testing.T
+	ctx       context.Context
 	responses []*sm.Tank
+	header    metadata.MD
+	trailer   metadata.MD
 	grpc.ServerStream
 }
 
+func (x *MockTankService) SendHeader(md metadata.MD) error {
+	x.header = metadata.Join(x.header, md)
+	return nil
+}
+
+func (x *MockTankService) SetTrailer(md metadata.MD) {
+	x.trailer = metadata.Join(x.trailer, md)
+}
+
+func (x *MockTankService) Context() context.Context {
+	if x.ctx == nil {
+		return context.Background()
+	}
+	return x.ctx
+}
+
 func (x *MockTankService) Send(resp *tankProto.TankResponse) error {
 	compareResponseToTank(x.t, resp, x.responses[x.CallCount])
 	x.CallCount++
@@ -317,6 +1029,9 @@ func compareResponseToTank(t *testing.T, resp *tankProto.TankResponse, tank *sm.
 	if tank.FishCount != resp.FishCount {
 		t.Errorf("last cleaned do not match, expected: %d | actual: %d", tank.FishCount, resp.FishCount)
 	}
+	if tank.Lab != resp.Lab {
+		t.Errorf("labs do not match, expected: %s | actual: %s", tank.Lab, resp.Lab)
+	}
 }
 
 func validateError(t *testing.T, err error, code codes.Code, expected bool) bool {
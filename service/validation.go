@@ -0,0 +1,112 @@
+package service
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/anchamber/genetics-tank/db/model"
+	pb "github.com/anchamber/genetics-tank/proto"
+	"google.golang.org/genproto/googleapis/rpc/errdetails"
+	"google.golang.org/protobuf/reflect/protoreflect"
+	"google.golang.org/protobuf/types/known/fieldmaskpb"
+)
+
+// MaxFishCount is the maximum number of fish a tank can hold.
+const MaxFishCount = 1000
+
+// fieldRule validates a single field of a tank. check returns a description
+// of the violation or "" if the value is valid.
+type fieldRule struct {
+	// field is the name of the field in pb.Tank.
+	field string
+	check func(tank *model.Tank) string
+}
+
+var tankRules = []fieldRule{
+	{field: "number", check: func(tank *model.Tank) string {
+		if tank.Number == 0 {
+			return "number must not be 0"
+		}
+		return ""
+	}},
+	{field: "system", check: func(tank *model.Tank) string {
+		if strings.TrimSpace(tank.System) == "" {
+			return "system must not be empty"
+		}
+		return ""
+	}},
+	{field: "size", check: func(tank *model.Tank) string {
+		if tank.Size == 0 {
+			return "size must be positive"
+		}
+		return ""
+	}},
+	{field: "fishCount", check: func(tank *model.Tank) string {
+		if tank.FishCount > MaxFishCount {
+			return fmt.Sprintf("fish count must be at most %d", MaxFishCount)
+		}
+		return ""
+	}},
+}
+
+// immutableFields can only be set when a tank is created.
+var immutableFields = map[string]bool{
+	"number": true,
+}
+
+// validateTank checks tank against tankRules and returns all violations. If
+// fields is not empty, only the rules of those fields are checked.
+// Violations are reported for the field prefixed with prefix, e.g. "tank.".
+func validateTank(tank *model.Tank, prefix string, fields ...string) []*errdetails.BadRequest_FieldViolation {
+	var violations []*errdetails.BadRequest_FieldViolation
+	for _, rule := range tankRules {
+		if len(fields) > 0 && !contains(fields, rule.field) {
+			continue
+		}
+		if description := rule.check(tank); description != "" {
+			violations = append(violations, &errdetails.BadRequest_FieldViolation{
+				Field:       prefix + rule.field,
+				Description: description,
+			})
+		}
+	}
+	return violations
+}
+
+// validateUpdateMask checks that mask names at least one field and only
+// mutable fields of pb.Tank.
+func validateUpdateMask(mask *fieldmaskpb.FieldMask, field string) []*errdetails.BadRequest_FieldViolation {
+	if len(mask.GetPaths()) == 0 {
+		return []*errdetails.BadRequest_FieldViolation{{
+			Field:       field,
+			Description: "mask must name at least one field",
+		}}
+	}
+	var violations []*errdetails.BadRequest_FieldViolation
+	fields := (&pb.Tank{}).ProtoReflect().Descriptor().Fields()
+	for i, path := range mask.GetPaths() {
+		var description string
+		switch {
+		case fields.ByName(protoreflect.Name(path)) == nil:
+			description = fmt.Sprintf("unknown tank field %q", path)
+		case immutableFields[path]:
+			description = fmt.Sprintf("tank field %q can not be updated", path)
+		default:
+			continue
+		}
+		violations = append(violations, &errdetails.BadRequest_FieldViolation{
+			Field:       fmt.Sprintf("%s.paths[%d]", field, i),
+			Description: description,
+		})
+	}
+	return violations
+}
+
+func contains(values []string, value string) bool {
+	for _, v := range values {
+		if v == value {
+			return true
+		}
+	}
+	return false
+}
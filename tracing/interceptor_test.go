@@ -0,0 +1,47 @@
+package tracing_test
+
+import (
+	"context"
+	"testing"
+
+	"github.com/anchamber/genetics-tank/tracing"
+	"go.opentelemetry.io/otel"
+	"go.opentelemetry.io/otel/codes"
+	"go.opentelemetry.io/otel/propagation"
+	sdktrace "go.opentelemetry.io/otel/sdk/trace"
+	"go.opentelemetry.io/otel/sdk/trace/tracetest"
+	"google.golang.org/grpc"
+	grpcCodes "google.golang.org/grpc/codes"
+	"google.golang.org/grpc/metadata"
+	"google.golang.org/grpc/status"
+)
+
+func TestUnaryInterceptor(t *testing.T) {
+	recorder := tracetest.NewSpanRecorder()
+	otel.SetTracerProvider(sdktrace.NewTracerProvider(sdktrace.WithSpanProcessor(recorder)))
+	otel.SetTextMapPropagator(propagation.TraceContext{})
+
+	const traceID = "4bf92f3577b34da6a3ce929d0e0e4736"
+	ctx := metadata.NewIncomingContext(context.Background(), metadata.Pairs(
+		"traceparent", "00-"+traceID+"-00f067aa0ba902b7-01",
+	))
+	info := &grpc.UnaryServerInfo{FullMethod: "/anchamber.genetics.TankService/GetTank"}
+	_, _ = tracing.UnaryInterceptor(ctx, nil, info, func(ctx context.Context, req interface{}) (interface{}, error) {
+		return nil, status.Error(grpcCodes.NotFound, "not found")
+	})
+
+	spans := recorder.Ended()
+	if len(spans) != 1 {
+		t.Fatalf("expected 1 span, actual: %d", len(spans))
+	}
+	span := spans[0]
+	if span.Name() != "anchamber.genetics.TankService/GetTank" {
+		t.Errorf("span names do not match, expected: %s | actual: %s", "anchamber.genetics.TankService/GetTank", span.Name())
+	}
+	if span.SpanContext().TraceID().String() != traceID {
+		t.Errorf("trace ids do not match, expected: %s | actual: %s", traceID, span.SpanContext().TraceID())
+	}
+	if span.Status().Code != codes.Error {
+		t.Errorf("span status should be error, actual: %v", span.Status().Code)
+	}
+}
@@ -0,0 +1,92 @@
+package tracing
+
+import (
+	"bytes"
+	"context"
+	"encoding/base64"
+	"encoding/hex"
+	"encoding/json"
+	"io"
+	"sync"
+
+	coltracepb "go.opentelemetry.io/proto/otlp/collector/trace/v1"
+	tracepb "go.opentelemetry.io/proto/otlp/trace/v1"
+	"google.golang.org/protobuf/encoding/protojson"
+)
+
+// fileClient is the client of an otlptrace exporter that appends every batch
+// of spans to w as one line of OTLP/JSON, an ExportTraceServiceRequest in the
+// JSON encoding of OTLP. This is the format of the OpenTelemetry file
+// exporter, which the otlpjsonfile receiver of the collector reads.
+type fileClient struct {
+	mu sync.Mutex
+	w  io.Writer
+}
+
+func (c *fileClient) Start(context.Context) error {
+	return nil
+}
+
+func (c *fileClient) Stop(context.Context) error {
+	return nil
+}
+
+func (c *fileClient) UploadTraces(ctx context.Context, spans []*tracepb.ResourceSpans) error {
+	line, err := marshalOTLPJSON(&coltracepb.ExportTraceServiceRequest{ResourceSpans: spans})
+	if err != nil {
+		return err
+	}
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	_, err = c.w.Write(append(line, '\n'))
+	return err
+}
+
+// otlpIDs are the fields holding trace and span IDs. OTLP/JSON encodes them
+// in hex instead of the base64 of the protobuf JSON mapping.
+var otlpIDs = map[string]bool{"traceId": true, "spanId": true, "parentSpanId": true}
+
+// marshalOTLPJSON encodes request as OTLP/JSON on a single line.
+func marshalOTLPJSON(request *coltracepb.ExportTraceServiceRequest) ([]byte, error) {
+	encoded, err := protojson.MarshalOptions{UseEnumNumbers: true}.Marshal(request)
+	if err != nil {
+		return nil, err
+	}
+	decoder := json.NewDecoder(bytes.NewReader(encoded))
+	decoder.UseNumber()
+	var value interface{}
+	if err := decoder.Decode(&value); err != nil {
+		return nil, err
+	}
+	if err := hexIDs(value); err != nil {
+		return nil, err
+	}
+	return json.Marshal(value)
+}
+
+// hexIDs re-encodes the IDs in the decoded JSON value in hex.
+func hexIDs(value interface{}) error {
+	switch value := value.(type) {
+	case map[string]interface{}:
+		for key, field := range value {
+			if id, ok := field.(string); ok && otlpIDs[key] {
+				raw, err := base64.StdEncoding.DecodeString(id)
+				if err != nil {
+					return err
+				}
+				value[key] = hex.EncodeToString(raw)
+				continue
+			}
+			if err := hexIDs(field); err != nil {
+				return err
+			}
+		}
+	case []interface{}:
+		for _, element := range value {
+			if err := hexIDs(element); err != nil {
+				return err
+			}
+		}
+	}
+	return nil
+}
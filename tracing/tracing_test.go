@@ -0,0 +1,124 @@
+package tracing_test
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/anchamber/genetics-tank/tracing"
+	"go.opentelemetry.io/otel"
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/codes"
+	"go.opentelemetry.io/otel/trace"
+)
+
+// otlpLine is the part of an OTLP/JSON ExportTraceServiceRequest checked by
+// TestFileExporter.
+type otlpLine struct {
+	ResourceSpans []struct {
+		Resource struct {
+			Attributes []otlpAttribute `json:"attributes"`
+		} `json:"resource"`
+		ScopeSpans []struct {
+			Scope struct {
+				Name string `json:"name"`
+			} `json:"scope"`
+			Spans []struct {
+				TraceID           string          `json:"traceId"`
+				SpanID            string          `json:"spanId"`
+				ParentSpanID      string          `json:"parentSpanId"`
+				Name              string          `json:"name"`
+				Kind              int             `json:"kind"`
+				StartTimeUnixNano string          `json:"startTimeUnixNano"`
+				Attributes        []otlpAttribute `json:"attributes"`
+				Status            struct {
+					Message string `json:"message"`
+					Code    int    `json:"code"`
+				} `json:"status"`
+			} `json:"spans"`
+		} `json:"scopeSpans"`
+	} `json:"resourceSpans"`
+}
+
+type otlpAttribute struct {
+	Key   string                     `json:"key"`
+	Value map[string]json.RawMessage `json:"value"`
+}
+
+func TestFileExporter(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "traces.jsonl")
+	shutdown, err := tracing.Setup("file", path)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	ctx, parent := otel.Tracer("test").Start(context.Background(), "parent", trace.WithSpanKind(trace.SpanKindServer))
+	_, child := otel.Tracer("test").Start(ctx, "child", trace.WithAttributes(
+		attribute.Int64("tank.number", 42),
+		attribute.StringSlice("tank.systems", []string{"A", "B"}),
+	))
+	child.SetStatus(codes.Error, "failed")
+	child.End()
+	parent.End()
+	if err := shutdown(context.Background()); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	content, err := os.ReadFile(path)
+	if err != nil {
+		t.Fatal(err)
+	}
+	lines := bytes.Split(bytes.TrimSpace(content), []byte("\n"))
+	if len(lines) != 1 {
+		t.Fatalf("expected 1 line, actual: %d", len(lines))
+	}
+	var line otlpLine
+	if err := json.Unmarshal(lines[0], &line); err != nil {
+		t.Fatalf("invalid json: %v", err)
+	}
+	if len(line.ResourceSpans) != 1 || len(line.ResourceSpans[0].ScopeSpans) != 1 {
+		t.Fatalf("spans are not grouped by resource and scope: %s", lines[0])
+	}
+	if !hasAttribute(line.ResourceSpans[0].Resource.Attributes, "service.name", `{"stringValue":"genetics-tank"}`) {
+		t.Errorf("resource has no service name: %s", lines[0])
+	}
+	spans := line.ResourceSpans[0].ScopeSpans[0].Spans
+	if len(spans) != 2 {
+		t.Fatalf("expected 2 spans, actual: %d", len(spans))
+	}
+	childSpan, parentSpan := spans[0], spans[1]
+	if parentSpan.TraceID != parent.SpanContext().TraceID().String() || parentSpan.SpanID != parent.SpanContext().SpanID().String() {
+		t.Errorf("ids are not hex encoded: %s %s", parentSpan.TraceID, parentSpan.SpanID)
+	}
+	if childSpan.ParentSpanID != parentSpan.SpanID {
+		t.Errorf("wrong parent, expected: %s | actual: %s", parentSpan.SpanID, childSpan.ParentSpanID)
+	}
+	if parentSpan.Kind != 2 || childSpan.Kind != 1 {
+		t.Errorf("wrong kinds, expected: 2 and 1 | actual: %d and %d", parentSpan.Kind, childSpan.Kind)
+	}
+	if childSpan.Status.Code != 2 || childSpan.Status.Message != "failed" {
+		t.Errorf("wrong status: %+v", childSpan.Status)
+	}
+	if childSpan.StartTimeUnixNano == "" || childSpan.StartTimeUnixNano == "0" {
+		t.Error("missing start time")
+	}
+	if !hasAttribute(childSpan.Attributes, "tank.number", `{"intValue":"42"}`) {
+		t.Errorf("wrong int attribute: %s", lines[0])
+	}
+	if !hasAttribute(childSpan.Attributes, "tank.systems", `{"arrayValue":{"values":[{"stringValue":"A"},{"stringValue":"B"}]}}`) {
+		t.Errorf("wrong array attribute: %s", lines[0])
+	}
+}
+
+func hasAttribute(attributes []otlpAttribute, key string, value string) bool {
+	for _, attribute := range attributes {
+		if attribute.Key != key {
+			continue
+		}
+		encoded, _ := json.Marshal(attribute.Value)
+		return string(encoded) == value
+	}
+	return false
+}